@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+)
+
+// tabelaContagem retorna, para cada k de 0 a n, quantas cadeias de tamanho k cada estado de um AFD
+// aceita. Cada cadeia corresponde a um único caminho no AFD, por isso a contagem é exata.
+func (AFD *Automato[S]) tabelaContagem(n int) []map[string]*big.Int {
+	simbolos := AFD.simbolosOrdenados()
+	contagem := make([]map[string]*big.Int, n+1)
+	contagem[0] = make(map[string]*big.Int, len(AFD.Estados))
+	for _, estado := range AFD.Estados {
+		contagem[0][estado] = big.NewInt(0)
+	}
+	for _, final := range AFD.EstadosFinais {
+		contagem[0][final] = big.NewInt(1)
+	}
+
+	for k := 1; k <= n; k++ {
+		contagem[k] = make(map[string]*big.Int, len(AFD.Estados))
+		for _, estado := range AFD.Estados {
+			total := big.NewInt(0)
+			for _, simbolo := range simbolos {
+				if destinos := AFD.Transicoes[estado][simbolo]; len(destinos) > 0 {
+					total.Add(total, contagem[k-1][destinos[0]])
+				}
+			}
+			contagem[k][estado] = total
+		}
+	}
+	return contagem
+}
+
+// contarAceitas retorna o número de cadeias de tamanho exatamente n aceitas pelo autômato.
+func (AF *Automato[S]) contarAceitas(n int) *big.Int {
+	AFD := AF.determinizar()
+	return AFD.tabelaContagem(n)[n][AFD.EstadoInicial]
+}
+
+// amostrarUniforme sorteia, com probabilidade uniforme, uma das cadeias de tamanho n aceitas pelo
+// autômato.
+func (AF *Automato[S]) amostrarUniforme(n int, gerador *rand.Rand) (string, error) {
+	amostras, _, err := AF.amostrarUniformes(n, 1, gerador)
+	if err != nil {
+		return "", err
+	}
+	return amostras[0], nil
+}
+
+// amostrarUniformes sorteia quantidade cadeias de tamanho n aceitas pelo autômato, cada uma com
+// probabilidade uniforme, e retorna também quantas são ao todo. O autômato é determinizado e contado
+// uma única vez; a partir da contagem de cadeias por estado, cada símbolo é escolhido com peso
+// proporcional ao número de cadeias aceitas que começam por ele.
+func (AF *Automato[S]) amostrarUniformes(n, quantidade int, gerador *rand.Rand) ([]string, *big.Int, error) {
+	if n < 0 {
+		return nil, nil, fmt.Errorf("tamanho inválido: %d", n)
+	}
+	AFD := AF.determinizar()
+	contagem := AFD.tabelaContagem(n)
+	total := contagem[n][AFD.EstadoInicial]
+	if total.Sign() == 0 && quantidade > 0 {
+		return nil, total, fmt.Errorf("nenhuma cadeia de tamanho %d é aceita", n)
+	}
+
+	simbolos := AFD.simbolosOrdenados()
+	amostras := make([]string, 0, quantidade)
+	for range quantidade {
+		sorteio := new(big.Int).Rand(gerador, total)
+		cadeia := make([]S, 0, n)
+		estado := AFD.EstadoInicial
+		for k := n; k > 0; k-- {
+			for _, simbolo := range simbolos {
+				destinos := AFD.Transicoes[estado][simbolo]
+				if len(destinos) == 0 {
+					continue
+				}
+				aceitas := contagem[k-1][destinos[0]]
+				if sorteio.Cmp(aceitas) < 0 {
+					cadeia = append(cadeia, simbolo)
+					estado = destinos[0]
+					break
+				}
+				sorteio.Sub(sorteio, aceitas)
+			}
+		}
+		amostras = append(amostras, formatarEntrada(cadeia))
+	}
+	return amostras, total, nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestContarAceitas(t *testing.T) {
+	// Termina com "ab"
+	nfa := AutomatoFinito{
+		Estados:  []string{"q0", "q1", "q2"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"q0": {'a': {"q0", "q1"}, 'b': {"q0"}},
+			"q1": {'b': {"q2"}},
+		},
+		EstadoInicial: "q0",
+		EstadosFinais: []string{"q2"},
+	}
+
+	// 2^(n-2) cadeias de tamanho n >= 2; o AFN tem dois caminhos para algumas, mas cada uma conta uma vez
+	tests := []struct {
+		n        int
+		expected string
+	}{
+		{0, "0"},
+		{1, "0"},
+		{2, "1"},
+		{5, "8"},
+		{70, "295147905179352825856"}, // 2^68, maior que uint64
+	}
+	for _, tt := range tests {
+		if got := nfa.contarAceitas(tt.n).String(); got != tt.expected {
+			t.Errorf("contarAceitas(%d) = %s, want %s", tt.n, got, tt.expected)
+		}
+	}
+}
+
+func TestAmostrarUniforme(t *testing.T) {
+	// a*b*: cadeias de tamanho 3 são aaa, aab, abb, bbb
+	nfa := AutomatoFinito{
+		Estados:  []string{"qa", "qb"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"qa": {'a': {"qa"}},
+			"qb": {'b': {"qb"}},
+		},
+		TransicoesEpsilon: map[string][]string{"qa": {"qb"}},
+		EstadoInicial:     "qa",
+		EstadosFinais:     []string{"qb"},
+	}
+
+	gerador := rand.New(rand.NewSource(7))
+	frequencia := make(map[string]int)
+	for range 4000 {
+		cadeia, err := nfa.amostrarUniforme(3, gerador)
+		if err != nil {
+			t.Fatalf("amostrarUniforme() erro inesperado: %v", err)
+		}
+		frequencia[cadeia]++
+	}
+	for _, cadeia := range []string{"aaa", "aab", "abb", "bbb"} {
+		if frequencia[cadeia] < 850 || frequencia[cadeia] > 1150 {
+			t.Errorf("frequência de %q = %d/4000, esperado perto de 1000", cadeia, frequencia[cadeia])
+		}
+	}
+	if len(frequencia) != 4 {
+		t.Errorf("amostras fora da linguagem: %v", frequencia)
+	}
+
+	// Mesma semente, mesma sequência
+	a, _ := nfa.amostrarUniforme(3, rand.New(rand.NewSource(1)))
+	b, _ := nfa.amostrarUniforme(3, rand.New(rand.NewSource(1)))
+	if a != b {
+		t.Errorf("a mesma semente gerou %q e %q", a, b)
+	}
+}
+
+func TestAmostrarUniformeSemCadeias(t *testing.T) {
+	// Aceita apenas "ab"
+	dfa := AutomatoFinito{
+		Estados:  []string{"q0", "q1", "q2"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"q0": {'a': {"q1"}},
+			"q1": {'b': {"q2"}},
+		},
+		EstadoInicial: "q0",
+		EstadosFinais: []string{"q2"},
+	}
+	_, err := dfa.amostrarUniforme(3, rand.New(rand.NewSource(1)))
+	if err == nil || !strings.Contains(err.Error(), "tamanho 3") {
+		t.Errorf("amostrarUniforme(3) erro = %v, want erro de tamanho 3", err)
+	}
+	if cadeia, err := dfa.amostrarUniforme(2, rand.New(rand.NewSource(1))); err != nil || cadeia != "ab" {
+		t.Errorf("amostrarUniforme(2) = (%q, %v), want (\"ab\", nil)", cadeia, err)
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// AreaTrabalho guarda vários autômatos por nome, cada um com sua própria sessão (e histórico).
+// Os comandos do console agem sobre o autômato atual.
+type AreaTrabalho struct {
+	sessoes map[string]*Sessao
+	atual   string
+}
+
+const nomeAutomatoPadrao = "principal"
+
+func novaAreaTrabalho() *AreaTrabalho {
+	return &AreaTrabalho{
+		sessoes: map[string]*Sessao{nomeAutomatoPadrao: novaSessao(AutomatoFinito{})},
+		atual:   nomeAutomatoPadrao,
+	}
+}
+
+// sessaoAtual retorna a sessão do autômato em uso.
+func (A *AreaTrabalho) sessaoAtual() *Sessao {
+	return A.sessoes[A.atual]
+}
+
+func (A *AreaTrabalho) nomes() []string {
+	return chavesOrdenadas(A.sessoes)
+}
+
+func (A *AreaTrabalho) buscar(nome string) (*Sessao, error) {
+	sessao, ok := A.sessoes[nome]
+	if !ok {
+		return nil, fmt.Errorf("não há autômato chamado '%s'", nome)
+	}
+	return sessao, nil
+}
+
+// adicionar guarda o autômato com um histórico novo. O nome não pode estar em uso.
+func (A *AreaTrabalho) adicionar(nome string, AF AutomatoFinito) error {
+	if nome == "" {
+		return fmt.Errorf("nome do autômato não pode ser vazio")
+	}
+	if _, ok := A.sessoes[nome]; ok {
+		return fmt.Errorf("já existe um autômato chamado '%s'", nome)
+	}
+	A.sessoes[nome] = novaSessao(AF)
+	return nil
+}
+
+// nomeLivre retorna base seguido do menor número que ainda não é nome de um autômato.
+func (A *AreaTrabalho) nomeLivre(base string) string {
+	for i := 1; ; i++ {
+		if _, ok := A.sessoes[base+strconv.Itoa(i)]; !ok {
+			return base + strconv.Itoa(i)
+		}
+	}
+}
+
+// guardarSessao guarda uma sessão criada fora do console com o primeiro nome livre base1, base2, ...
+// e retorna o nome usado.
+func (A *AreaTrabalho) guardarSessao(base string, sessao *Sessao) string {
+	nome := A.nomeLivre(base)
+	A.sessoes[nome] = sessao
+	return nome
+}
+
+func (A *AreaTrabalho) usar(nome string) error {
+	if _, err := A.buscar(nome); err != nil {
+		return err
+	}
+	A.atual = nome
+	return nil
+}
+
+func (A *AreaTrabalho) copiar(origem, destino string) error {
+	sessao, err := A.buscar(origem)
+	if err != nil {
+		return err
+	}
+	return A.adicionar(destino, sessao.Automato)
+}
+
+// remover apaga o autômato. O autômato atual não pode ser removido.
+func (A *AreaTrabalho) remover(nome string) error {
+	if _, err := A.buscar(nome); err != nil {
+		return err
+	}
+	if nome == A.atual {
+		return fmt.Errorf("'%s' está em uso; troque de autômato com \"use\" antes de removê-lo", nome)
+	}
+	delete(A.sessoes, nome)
+	return nil
+}
+
+// operar aplica uma operação binária aos autômatos a e b e guarda o resultado como destino.
+func (A *AreaTrabalho) operar(operacao func(a, b *AutomatoFinito) AutomatoFinito, a, b, destino string) error {
+	sessaoA, err := A.buscar(a)
+	if err != nil {
+		return err
+	}
+	sessaoB, err := A.buscar(b)
+	if err != nil {
+		return err
+	}
+	for i, sessao := range []*Sessao{sessaoA, sessaoB} {
+		if sessao.Automato.EstadoInicial == "" {
+			return fmt.Errorf("'%s' não tem estado inicial", []string{a, b}[i])
+		}
+	}
+	return A.adicionar(destino, operacao(&sessaoA.Automato, &sessaoB.Automato))
+}
+
+// menuAreaTrabalho oferece as operações da área de trabalho por menu, executando os mesmos comandos
+// do console.
+func menuAreaTrabalho(area *AreaTrabalho) {
+	C := novoConsole(area)
+	opcoes := []struct {
+		descricao string
+		comando   string
+		prompts   []string
+	}{
+		{"Listar autômatos", "list", nil},
+		{"União", "union", []string{"Primeiro autômato: ", "Segundo autômato: ", "Nome do resultado: "}},
+		{"Interseção", "intersect", []string{"Primeiro autômato: ", "Segundo autômato: ", "Nome do resultado: "}},
+		{"Diferença", "diff", []string{"Primeiro autômato: ", "Segundo autômato: ", "Nome do resultado: "}},
+		{"Equivalência", "equiv", []string{"Primeiro autômato: ", "Segundo autômato: "}},
+		{"Isomorfismo", "iso", []string{"Primeiro autômato: ", "Segundo autômato: "}},
+		{"Copiar autômato", "copy", []string{"Autômato: ", "Nome da cópia: "}},
+		{"Remover autômato", "delete", []string{"Autômato: "}},
+		{"Trocar o autômato atual", "use", []string{"Autômato: "}},
+		{"Salvar o autômato atual em arquivo", "save", []string{"Arquivo: "}},
+		{"Carregar arquivo no autômato atual", "load", []string{"Arquivo: "}},
+	}
+	for {
+		fmt.Printf("\n--- Área de Trabalho (atual: '%s') ---\n", area.atual)
+		for i, opcao := range opcoes {
+			fmt.Printf("%d. %s\n", i+1, opcao.descricao)
+		}
+		fmt.Println("0. Voltar ao menu principal")
+		fmt.Print("Escolha uma opção: ")
+
+		escolha, ok := lerOpcao()
+		if !ok || escolha == 0 {
+			return
+		}
+		if escolha < 1 || escolha > len(opcoes) {
+			fmt.Println("Opção inválida, tente novamente.")
+			continue
+		}
+		opcao := opcoes[escolha-1]
+		args, ok := leituraArgumentos(opcao.prompts...)
+		if !ok {
+			return
+		}
+		linha := opcao.comando
+		for _, arg := range args {
+			linha += " " + codificarCadeia(arg)
+		}
+		saida, err := C.executarLinha(linha)
+		if err != nil {
+			fmt.Printf("Erro: %v.\n", err)
+			continue
+		}
+		fmt.Print(saida)
+	}
+}
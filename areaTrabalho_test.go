@@ -0,0 +1,77 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAreaTrabalhoComandos(t *testing.T) {
+	C := novoConsole(novaAreaTrabalho())
+	executar := func(linha string) string {
+		t.Helper()
+		saida, err := C.executarLinha(linha)
+		if err != nil {
+			t.Fatalf("executarLinha(%q) erro inesperado: %v", linha, err)
+		}
+		return saida
+	}
+	// "principal" aceita as cadeias com número par de a's; "termina" as que terminam com b
+	for _, linha := range []string{
+		"state p", "state i", "symbol a", "symbol b", "start p", "final p",
+		"trans p a i", "trans i a p", "trans p b p", "trans i b i",
+		"new termina", "state s", "state f", "symbol a", "symbol b", "start s", "final f",
+		"trans s a s", "trans s b s", "trans s b f",
+	} {
+		executar(linha)
+	}
+
+	if saida := executar("intersect principal termina ambos"); saida != "'ambos' criado com 4 estados.\n" {
+		t.Errorf("intersect = %q", saida)
+	}
+	C.area.usar("ambos")
+	for cadeia, expected := range map[string]string{"aab": "aceita", "ab": "não aceita", "b": "aceita", "aa": "não aceita"} {
+		if saida := executar("test " + cadeia); !strings.HasSuffix(saida, ": "+expected+"\n") {
+			t.Errorf("test %s em ambos = %q, want %s", cadeia, saida, expected)
+		}
+	}
+	if estados := C.area.sessaoAtual().Automato.Estados; estados[0] != "q0" {
+		t.Errorf("resultado deveria ter estados canônicos, tem %q", estados)
+	}
+
+	executar("union principal termina alguma")
+	executar("diff alguma termina so_par")
+	if saida := executar("equiv so_par principal"); saida != "Não equivalentes: \"b\" é aceita por apenas um deles.\n" {
+		t.Errorf("equiv so_par principal = %q", saida)
+	}
+	executar("copy principal copia")
+	if saida := executar("equiv copia principal"); saida != "Equivalentes.\n" {
+		t.Errorf("equiv copia principal = %q", saida)
+	}
+	if saida := executar("iso copia principal"); saida != "Isomorfos: p → p, i → i\n" {
+		t.Errorf("iso copia principal = %q", saida)
+	}
+	if saida := executar("iso principal alguma"); saida != "Não isomorfos.\n" {
+		t.Errorf("iso principal alguma = %q", saida)
+	}
+
+	executar("delete copia")
+	if expected := []string{"alguma", "ambos", "principal", "so_par", "termina"}; !slices.Equal(C.area.nomes(), expected) {
+		t.Errorf("nomes() = %q, want %q", C.area.nomes(), expected)
+	}
+	if saida := executar("list"); !strings.Contains(saida, "* ambos (4 estados, 2 símbolos)\n") {
+		t.Errorf("list sem o autômato atual marcado:\n%s", saida)
+	}
+
+	for linha, erro := range map[string]string{
+		"delete ambos":            "está em uso",
+		"use nenhum":              "não há autômato chamado 'nenhum'",
+		"new termina":             "já existe",
+		"union principal termina": "union espera 3 argumento(s)",
+		"iso principal termina":   "não é determinístico",
+	} {
+		if _, err := C.executarLinha(linha); err == nil || !strings.Contains(err.Error(), erro) {
+			t.Errorf("executarLinha(%q) erro = %v, want contendo %q", linha, err, erro)
+		}
+	}
+}
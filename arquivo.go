@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// automatoJSON é a forma do autômato nos arquivos .json. Símbolos são gravados como cadeias de um
+// caractere, e não como o número da runa, para que o arquivo possa ser escrito à mão.
+type automatoJSON struct {
+	Estados           []string        `json:"estados"`
+	Alfabeto          []string        `json:"alfabeto"`
+	Transicoes        []transicaoJSON `json:"transicoes"`
+	TransicoesEpsilon []transicaoJSON `json:"transicoesEpsilon,omitempty"`
+	EstadoInicial     string          `json:"estadoInicial"`
+	EstadosFinais     []string        `json:"estadosFinais"`
+}
+
+type transicaoJSON struct {
+	Origem   string   `json:"origem"`
+	Simbolo  string   `json:"simbolo,omitempty"`
+	Destinos []string `json:"destinos"`
+}
+
+// validarEpsilon detecta a codificação antiga de épsilon, em que o símbolo 'ε' em Transicoes era a
+// transição vazia. Uma transição com 'ε' que não está no alfabeto é ambígua: se é épsilon, deve ir
+// para TransicoesEpsilon; se é o símbolo, ε deve ser declarado no alfabeto.
+func validarEpsilon(AF *AutomatoFinito) error {
+	if slices.Contains(AF.Alfabeto, epsilonRune) {
+		return nil
+	}
+	for _, origem := range chavesOrdenadas(AF.Transicoes) {
+		if len(AF.Transicoes[origem][epsilonRune]) > 0 {
+			return fmt.Errorf("transição de %s com o símbolo ε, que não está no alfabeto: transições épsilon ficam em transicoesEpsilon", origem)
+		}
+	}
+	return nil
+}
+
+func simboloDeTexto(texto string) (rune, error) {
+	r := []rune(texto)
+	if len(r) != 1 {
+		return 0, fmt.Errorf("símbolo %q deve ter exatamente um caractere", texto)
+	}
+	return r[0], nil
+}
+
+// paraJSON serializa o autômato com as transições ordenadas por origem (em ordem natural) e símbolo.
+func paraJSON(AF *AutomatoFinito) ([]byte, error) {
+	dados := automatoJSON{
+		Estados:       AF.Estados,
+		EstadoInicial: AF.EstadoInicial,
+		EstadosFinais: AF.EstadosFinais,
+		Alfabeto:      []string{},
+		Transicoes:    []transicaoJSON{},
+	}
+	for _, simbolo := range AF.Alfabeto {
+		dados.Alfabeto = append(dados.Alfabeto, string(simbolo))
+	}
+	for _, origem := range chavesOrdenadas(AF.Transicoes) {
+		for _, simbolo := range slices.Sorted(maps.Keys(AF.Transicoes[origem])) {
+			dados.Transicoes = append(dados.Transicoes, transicaoJSON{origem, string(simbolo), AF.Transicoes[origem][simbolo]})
+		}
+	}
+	for _, origem := range chavesOrdenadas(AF.TransicoesEpsilon) {
+		dados.TransicoesEpsilon = append(dados.TransicoesEpsilon, transicaoJSON{Origem: origem, Destinos: AF.TransicoesEpsilon[origem]})
+	}
+	return json.MarshalIndent(dados, "", "  ")
+}
+
+// automatoDeJSON reconstrói o autômato pelos métodos adicionar*, validando os símbolos. Estados e
+// símbolos usados sem declaração são rejeitados aqui, e não depois, ao exibir ou salvar.
+func automatoDeJSON(conteudo []byte) (AutomatoFinito, error) {
+	var dados automatoJSON
+	if err := json.Unmarshal(conteudo, &dados); err != nil {
+		return AutomatoFinito{}, err
+	}
+
+	AF := AutomatoFinito{}
+	for _, estado := range dados.Estados {
+		AF.adicionarEstado(estado)
+	}
+	for _, texto := range dados.Alfabeto {
+		simbolo, err := simboloDeTexto(texto)
+		if err != nil {
+			return AutomatoFinito{}, fmt.Errorf("alfabeto: %w", err)
+		}
+		AF.adicionarAlfabeto(simbolo)
+	}
+	for _, transicao := range dados.Transicoes {
+		simbolo, err := simboloDeTexto(transicao.Simbolo)
+		if err != nil {
+			return AutomatoFinito{}, fmt.Errorf("transição de %s: %w", transicao.Origem, err)
+		}
+		for _, destino := range transicao.Destinos {
+			AF.adicionarTransicao(transicao.Origem, simbolo, destino)
+		}
+	}
+	for _, transicao := range dados.TransicoesEpsilon {
+		if transicao.Simbolo != "" {
+			return AutomatoFinito{}, fmt.Errorf("transição épsilon de %s não deve ter símbolo", transicao.Origem)
+		}
+		for _, destino := range transicao.Destinos {
+			AF.adicionarTransicaoEpsilon(transicao.Origem, destino)
+		}
+	}
+	AF.adicionarEstadoInicial(dados.EstadoInicial)
+	for _, final := range dados.EstadosFinais {
+		AF.adicionarEstadoFinal(final)
+	}
+	if err := validarEpsilon(&AF); err != nil {
+		return AutomatoFinito{}, err
+	}
+	if err := verificarDeclarados(&AF); err != nil {
+		return AutomatoFinito{}, err
+	}
+	return AF, nil
+}
+
+// salvarAutomato escolhe o formato pela extensão: .af (formato texto), .afc (formato texto com
+// classes), .csv e .md (tabela de transições), .gr (gramática linear à direita) ou JSON para as demais.
+func salvarAutomato(caminho string, AF *AutomatoFinito) error {
+	if err := validarEpsilon(AF); err != nil {
+		return err
+	}
+	var conteudo []byte
+	switch strings.ToLower(filepath.Ext(caminho)) {
+	case ".af":
+		texto, err := paraDSL(AF)
+		if err != nil {
+			return err
+		}
+		conteudo = []byte(texto)
+	case ".afc":
+		if err := verificarDeclarados(AF); err != nil {
+			return err
+		}
+		conteudo = []byte(paraClasses(AF).String())
+	case ".csv":
+		T, err := tabelaTransicoes(AF)
+		if err != nil {
+			return err
+		}
+		conteudo = []byte(T.paraCSV())
+	case ".md":
+		T, err := tabelaTransicoes(AF)
+		if err != nil {
+			return err
+		}
+		conteudo = []byte(T.paraMarkdown())
+	case ".gr":
+		conteudo = []byte(paraGramatica(AF).String())
+	default:
+		dados, err := paraJSON(AF)
+		if err != nil {
+			return err
+		}
+		conteudo = append(dados, '\n')
+	}
+	return os.WriteFile(caminho, conteudo, 0o644)
+}
+
+// carregarAutomato lê o formato indicado pela extensão, como salvarAutomato. Tabelas em Markdown
+// só podem ser exportadas; uma gramática (linear à direita ou à esquerda) é convertida em AFN.
+func carregarAutomato(caminho string) (AutomatoFinito, error) {
+	conteudo, err := os.ReadFile(caminho)
+	if err != nil {
+		return AutomatoFinito{}, err
+	}
+	var AF AutomatoFinito
+	switch strings.ToLower(filepath.Ext(caminho)) {
+	case ".af":
+		AF, err = lerDSL(string(conteudo))
+	case ".csv":
+		AF, err = tabelaDeCSV(string(conteudo))
+	case ".md":
+		err = fmt.Errorf("tabelas em Markdown não podem ser carregadas; use .csv")
+	case ".afc":
+		err = fmt.Errorf("autômatos com classes não são carregados como AutomatoFinito; use o comando classes")
+	case ".gr":
+		var G Gramatica
+		if G, err = lerGramatica(string(conteudo)); err == nil {
+			AF = G.paraAutomato()
+		}
+	default:
+		AF, err = automatoDeJSON(conteudo)
+	}
+	if err != nil {
+		return AutomatoFinito{}, fmt.Errorf("%s: %w", caminho, err)
+	}
+	return AF, nil
+}
+
+// carregarAutomatoClasses lê um autômato com classes no formato texto (.afc) de lerDSLClasses.
+func carregarAutomatoClasses(caminho string) (AutomatoClasses, error) {
+	conteudo, err := os.ReadFile(caminho)
+	if err != nil {
+		return AutomatoClasses{}, err
+	}
+	AC, err := lerDSLClasses(string(conteudo))
+	if err != nil {
+		return AutomatoClasses{}, fmt.Errorf("%s: %w", caminho, err)
+	}
+	return AC, nil
+}
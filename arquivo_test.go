@@ -0,0 +1,194 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSalvarCarregarAutomato(t *testing.T) {
+	AF := AutomatoFinito{
+		Estados:  []string{"qe0", "qe1", "qe2"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"qe1": {'a': {"qe1"}, 'b': {"qe2"}},
+		},
+		TransicoesEpsilon: map[string][]string{"qe0": {"qe1"}},
+		EstadoInicial:     "qe0",
+		EstadosFinais:     []string{"qe2"},
+	}
+
+	caminho := filepath.Join(t.TempDir(), "automato.json")
+	if err := salvarAutomato(caminho, &AF); err != nil {
+		t.Fatalf("salvarAutomato() erro inesperado: %v", err)
+	}
+	carregado, err := carregarAutomato(caminho)
+	if err != nil {
+		t.Fatalf("carregarAutomato() erro inesperado: %v", err)
+	}
+	if !reflect.DeepEqual(carregado, AF) {
+		t.Errorf("carregarAutomato() = %+v, want %+v", carregado, AF)
+	}
+}
+
+func TestAutomatoDeJSONErros(t *testing.T) {
+	tests := []struct {
+		name     string
+		conteudo string
+		expected string
+	}{
+		{"JSON inválido", `{"estados": [`, "unexpected end"},
+		{"símbolo longo no alfabeto", `{"alfabeto": ["ab"]}`, "alfabeto"},
+		{"símbolo vazio na transição", `{"transicoes": [{"origem": "q0", "simbolo": "", "destinos": ["q0"]}]}`, "transição de q0"},
+		{"codificação antiga de épsilon", `{"alfabeto": ["a"], "transicoes": [{"origem": "q0", "simbolo": "ε", "destinos": ["q1"]}]}`, "transicoesEpsilon"},
+		{"épsilon com símbolo", `{"transicoesEpsilon": [{"origem": "q0", "simbolo": "a", "destinos": ["q1"]}]}`, "não deve ter símbolo"},
+		{"destino não declarado", `{"estados": ["q0"], "alfabeto": ["a"], "transicoes": [{"origem": "q0", "simbolo": "a", "destinos": ["q9"]}]}`, "transição q0,'a' --> q9: estado 'q9'"},
+		{"símbolo não declarado", `{"estados": ["q0"], "alfabeto": ["a"], "transicoes": [{"origem": "q0", "simbolo": "b", "destinos": ["q0"]}]}`, "símbolo 'b' não está no alfabeto"},
+		{"épsilon não declarado", `{"estados": ["q0"], "transicoesEpsilon": [{"origem": "q1", "destinos": ["q0"]}]}`, "transição q1,ε --> q0"},
+		{"inicial não declarado", `{"estados": ["q0"], "estadoInicial": "q1"}`, "estado inicial 'q1'"},
+		{"final não declarado", `{"estados": ["q0"], "estadoInicial": "q0", "estadosFinais": ["q1"]}`, "estado final 'q1'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := automatoDeJSON([]byte(tt.conteudo))
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("automatoDeJSON() erro = %v, want contendo %q", err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSimboloEpsilonNoAlfabeto(t *testing.T) {
+	// ε como letra grega do alfabeto convive com transições épsilon
+	AF := AutomatoFinito{
+		Estados:           []string{"q0", "q1", "q2"},
+		Alfabeto:          []rune{'α', 'ε'},
+		Transicoes:        map[string]map[rune][]string{"q0": {'ε': {"q1"}}, "q1": {'α': {"q2"}}},
+		TransicoesEpsilon: map[string][]string{"q0": {"q2"}},
+		EstadoInicial:     "q0",
+		EstadosFinais:     []string{"q2"},
+	}
+	for _, tt := range []struct {
+		cadeia   string
+		expected bool
+	}{{"", true}, {"εα", true}, {"α", false}, {"ε", false}} {
+		AF.adicionarCadeia(tt.cadeia)
+		if got := AF.funcionamento(); got != tt.expected {
+			t.Errorf("cadeia %q: got %v, want %v", tt.cadeia, got, tt.expected)
+		}
+	}
+
+	caminho := filepath.Join(t.TempDir(), "grego.json")
+	if err := salvarAutomato(caminho, &AF); err != nil {
+		t.Fatalf("salvarAutomato() erro inesperado: %v", err)
+	}
+	carregado, err := carregarAutomato(caminho)
+	if err != nil {
+		t.Fatalf("carregarAutomato() erro inesperado: %v", err)
+	}
+	AF.Cadeia = nil
+	if !reflect.DeepEqual(carregado, AF) {
+		t.Errorf("carregarAutomato() = %+v, want %+v", carregado, AF)
+	}
+
+	ambiguo := AutomatoFinito{
+		Estados:       []string{"q0"},
+		Alfabeto:      []rune{'a'},
+		Transicoes:    map[string]map[rune][]string{"q0": {'ε': {"q0"}}},
+		EstadoInicial: "q0",
+	}
+	if err := salvarAutomato(filepath.Join(t.TempDir(), "ambiguo.json"), &ambiguo); err == nil {
+		t.Errorf("salvarAutomato() com 'ε' fora do alfabeto deveria falhar")
+	}
+}
+
+func TestSalvarCarregarAutomatoDSL(t *testing.T) {
+	AF := AutomatoFinito{
+		Estados:       []string{"q0", "q1"},
+		Alfabeto:      []rune{'a', 'b'},
+		Transicoes:    map[string]map[rune][]string{"q0": {'a': {"q1"}, 'b': {"q1"}}},
+		EstadoInicial: "q0",
+		EstadosFinais: []string{"q1"},
+	}
+	caminho := filepath.Join(t.TempDir(), "automato.af")
+	if err := salvarAutomato(caminho, &AF); err != nil {
+		t.Fatalf("salvarAutomato() erro inesperado: %v", err)
+	}
+	conteudo, _ := os.ReadFile(caminho)
+	if !strings.Contains(string(conteudo), "q0 -a,b-> q1\n") {
+		t.Errorf("arquivo .af deveria estar no formato texto:\n%s", conteudo)
+	}
+	carregado, err := carregarAutomato(caminho)
+	if err != nil {
+		t.Fatalf("carregarAutomato() erro inesperado: %v", err)
+	}
+	if !reflect.DeepEqual(carregado, AF) {
+		t.Errorf("carregarAutomato() = %+v, want %+v", carregado, AF)
+	}
+
+	os.WriteFile(caminho, []byte("start q0\nq0 -ab-> q1\n"), 0o644)
+	if _, err := carregarAutomato(caminho); err == nil || !strings.HasSuffix(err.Error(), "automato.af: linha 2, coluna 5: \"ab\" não é um símbolo: use um caractere, ε ou [intervalo]") {
+		t.Errorf("carregarAutomato() erro = %v", err)
+	}
+}
+
+func TestSalvarCarregarGramatica(t *testing.T) {
+	AF := AutomatoFinito{
+		Estados:       []string{"q0", "q1"},
+		Alfabeto:      []rune{'a', 'b'},
+		Transicoes:    map[string]map[rune][]string{"q0": {'a': {"q0"}, 'b': {"q1"}}},
+		EstadoInicial: "q0",
+		EstadosFinais: []string{"q1"},
+	}
+	caminho := filepath.Join(t.TempDir(), "automato.gr")
+	if err := salvarAutomato(caminho, &AF); err != nil {
+		t.Fatalf("salvarAutomato() erro inesperado: %v", err)
+	}
+	if conteudo, _ := os.ReadFile(caminho); string(conteudo) != "S -> aS | bA\nA -> ε\n" {
+		t.Errorf("arquivo .gr =\n%s", conteudo)
+	}
+	carregado, err := carregarAutomato(caminho)
+	if err != nil {
+		t.Fatalf("carregarAutomato() erro inesperado: %v", err)
+	}
+	if ok, contraexemplo := equivalentes(&carregado, &AF); !ok {
+		t.Errorf("gramática relida não é equivalente: difere em %q", contraexemplo)
+	}
+
+	os.WriteFile(caminho, []byte("S -> aAB\n"), 0o644)
+	if _, err := carregarAutomato(caminho); err == nil || !strings.Contains(err.Error(), "automato.gr: linha 1") {
+		t.Errorf("carregarAutomato() erro = %v", err)
+	}
+}
+
+func TestSalvarCarregarClasses(t *testing.T) {
+	AF := AutomatoFinito{
+		Estados:       []string{"q0", "q1"},
+		Alfabeto:      []rune{'a', 'b', 'c'},
+		Transicoes:    map[string]map[rune][]string{"q0": {'a': {"q1"}, 'b': {"q1"}, 'c': {"q1"}}, "q1": {'a': {"q0"}}},
+		EstadoInicial: "q0",
+		EstadosFinais: []string{"q1"},
+	}
+	caminho := filepath.Join(t.TempDir(), "automato.afc")
+	if err := salvarAutomato(caminho, &AF); err != nil {
+		t.Fatalf("salvarAutomato() erro inesperado: %v", err)
+	}
+	esperado := "states q0 q1\nstart q0\nfinal q1\nq0 -[a-c]-> q1\nq1 -a-> q0\n"
+	if conteudo, _ := os.ReadFile(caminho); string(conteudo) != esperado {
+		t.Errorf("arquivo .afc =\n%s", conteudo)
+	}
+	AC, err := carregarAutomatoClasses(caminho)
+	if err != nil {
+		t.Fatalf("carregarAutomatoClasses() erro inesperado: %v", err)
+	}
+	for cadeia, expected := range map[string]bool{"b": true, "cab": true, "": false, "ca": false, "d": false} {
+		if got := AC.aceita(cadeia); got != expected {
+			t.Errorf("aceita(%q) = %v, want %v", cadeia, got, expected)
+		}
+	}
+	if _, err := carregarAutomato(caminho); err == nil || !strings.Contains(err.Error(), "use o comando classes") {
+		t.Errorf("carregarAutomato() erro = %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// AutomatoBuchi interpreta as transições de um AutomatoFinito sobre palavras infinitas: uma palavra
+// é aceita quando alguma execução passa infinitas vezes por estados de EstadosFinais.
+type AutomatoBuchi struct {
+	AutomatoFinito
+}
+
+// Laco é a palavra ultimamente periódica Prefixo·Ciclo^ω.
+type Laco struct {
+	Prefixo string
+	Ciclo   string
+}
+
+func (L Laco) String() string {
+	return fmt.Sprintf("%s(%s)^ω", L.Prefixo, L.Ciclo)
+}
+
+// Transições épsilon não são aceitas: eliminá-las como em eliminarEpsilon pode tornar final um
+// estado cujas execuções reais nunca passam pelo estado final alcançado por épsilon.
+var errBuchiEpsilon = errors.New("autômatos de Büchi não suportam transições épsilon")
+
+// arestaBuchi é uma aresta rotulada do grafo explorado pela busca em profundidade aninhada.
+type arestaBuchi[N comparable] struct {
+	simbolo rune
+	destino N
+}
+
+// aceita informa se a palavra Prefixo·Ciclo^ω é aceita, procurando um ciclo de aceitação no
+// produto do autômato com o laço (estado, posição na palavra Prefixo+Ciclo).
+func (B *AutomatoBuchi) aceita(laco Laco) (bool, error) {
+	if err := B.validarBuchi(); err != nil {
+		return false, err
+	}
+	if laco.Ciclo == "" {
+		return false, errors.New("o ciclo do laço não pode ser vazio")
+	}
+
+	type no struct {
+		estado  string
+		posicao int
+	}
+	palavra := []rune(laco.Prefixo + laco.Ciclo)
+	inicioCiclo := len([]rune(laco.Prefixo))
+	sucessores := func(n no) []arestaBuchi[no] {
+		proxima := n.posicao + 1
+		if proxima == len(palavra) {
+			proxima = inicioCiclo
+		}
+		var arestas []arestaBuchi[no]
+		for _, destino := range B.Transicoes[n.estado][palavra[n.posicao]] {
+			arestas = append(arestas, arestaBuchi[no]{palavra[n.posicao], no{destino, proxima}})
+		}
+		return arestas
+	}
+	ehFinal := func(n no) bool {
+		return slices.Contains(B.EstadosFinais, n.estado)
+	}
+
+	_, _, ok := buscaAninhada(no{B.EstadoInicial, 0}, sucessores, ehFinal)
+	return ok, nil
+}
+
+// vazio informa se a linguagem é vazia. Quando não é, retorna um laço aceito como testemunha.
+func (B *AutomatoBuchi) vazio() (bool, Laco, error) {
+	if err := B.validarBuchi(); err != nil {
+		return false, Laco{}, err
+	}
+
+	simbolos := B.simbolosOrdenados()
+	sucessores := func(estado string) []arestaBuchi[string] {
+		var arestas []arestaBuchi[string]
+		for _, simbolo := range simbolos {
+			for _, destino := range B.Transicoes[estado][simbolo] {
+				arestas = append(arestas, arestaBuchi[string]{simbolo, destino})
+			}
+		}
+		return arestas
+	}
+	ehFinal := func(estado string) bool {
+		return slices.Contains(B.EstadosFinais, estado)
+	}
+
+	prefixo, ciclo, ok := buscaAninhada(B.EstadoInicial, sucessores, ehFinal)
+	if !ok {
+		return true, Laco{}, nil
+	}
+	rotulos := func(arestas []arestaBuchi[string]) string {
+		var palavra []rune
+		for _, aresta := range arestas {
+			palavra = append(palavra, aresta.simbolo)
+		}
+		return string(palavra)
+	}
+	return false, Laco{rotulos(prefixo), rotulos(ciclo)}, nil
+}
+
+func (B *AutomatoBuchi) validarBuchi() error {
+	for _, destinos := range B.TransicoesEpsilon {
+		if len(destinos) > 0 {
+			return errBuchiEpsilon
+		}
+	}
+	return nil
+}
+
+// buscaAninhada é a busca em profundidade aninhada de Courcoubetis, Vardi, Wolper e Yannakakis.
+// A busca externa, ao terminar um nó de aceitação (pós-ordem), inicia uma busca interna que procura
+// um caminho de volta a ele. Retorna o caminho do inicial até esse nó e o ciclo que volta a ele.
+func buscaAninhada[N comparable](inicial N, sucessores func(N) []arestaBuchi[N], aceitacao func(N) bool) ([]arestaBuchi[N], []arestaBuchi[N], bool) {
+	visitadoExterno := make(map[N]bool)
+	visitadoInterno := make(map[N]bool)
+	var caminho, ciclo []arestaBuchi[N]
+
+	var interna func(no, semente N) bool
+	interna = func(no, semente N) bool {
+		visitadoInterno[no] = true
+		for _, aresta := range sucessores(no) {
+			ciclo = append(ciclo, aresta)
+			if aresta.destino == semente {
+				return true
+			}
+			if !visitadoInterno[aresta.destino] && interna(aresta.destino, semente) {
+				return true
+			}
+			ciclo = ciclo[:len(ciclo)-1]
+		}
+		return false
+	}
+
+	var externa func(no N) bool
+	externa = func(no N) bool {
+		visitadoExterno[no] = true
+		for _, aresta := range sucessores(no) {
+			if visitadoExterno[aresta.destino] {
+				continue
+			}
+			caminho = append(caminho, aresta)
+			if externa(aresta.destino) {
+				return true
+			}
+			caminho = caminho[:len(caminho)-1]
+		}
+		return aceitacao(no) && interna(no, no)
+	}
+
+	if externa(inicial) {
+		return caminho, ciclo, true
+	}
+	return nil, nil, false
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestAceitaBuchi(t *testing.T) {
+	// Infinitos 'a's: o estado final q1 é visitado a cada 'a'
+	infinitosA := AutomatoBuchi{AutomatoFinito{
+		Estados:  []string{"q0", "q1"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"q0": {'a': {"q1"}, 'b': {"q0"}},
+			"q1": {'a': {"q1"}, 'b': {"q0"}},
+		},
+		EstadoInicial: "q0",
+		EstadosFinais: []string{"q1"},
+	}}
+
+	// Finitos 'b's (não determinístico): adivinha o ponto a partir do qual só há 'a's
+	finitosB := AutomatoBuchi{AutomatoFinito{
+		Estados:  []string{"q0", "q1"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"q0": {'a': {"q0", "q1"}, 'b': {"q0"}},
+			"q1": {'a': {"q1"}},
+		},
+		EstadoInicial: "q0",
+		EstadosFinais: []string{"q1"},
+	}}
+
+	tests := []struct {
+		name     string
+		b        *AutomatoBuchi
+		laco     Laco
+		expected bool
+	}{
+		{"infinitosA_(a)^ω", &infinitosA, Laco{"", "a"}, true},
+		{"infinitosA_bbb(ab)^ω", &infinitosA, Laco{"bbb", "ab"}, true},
+		{"infinitosA_aaa(b)^ω", &infinitosA, Laco{"aaa", "b"}, false},
+		{"finitosB_bab(a)^ω", &finitosB, Laco{"bab", "a"}, true},
+		{"finitosB_(ab)^ω", &finitosB, Laco{"", "ab"}, false},
+		{"finitosB_a(ba)^ω", &finitosB, Laco{"a", "ba"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.b.aceita(tt.laco)
+			if err != nil {
+				t.Fatalf("aceita(%v) erro inesperado: %v", tt.laco, err)
+			}
+			if got != tt.expected {
+				t.Errorf("aceita(%v) = %v, want %v", tt.laco, got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := infinitosA.aceita(Laco{"a", ""}); err == nil {
+		t.Errorf("aceita com ciclo vazio deveria retornar erro")
+	}
+}
+
+func TestVazioBuchi(t *testing.T) {
+	// O final q2 é alcançável, mas não está em nenhum ciclo
+	semCiclo := AutomatoBuchi{AutomatoFinito{
+		Estados:  []string{"q0", "q1", "q2"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"q0": {'a': {"q0"}, 'b': {"q1"}},
+			"q1": {'a': {"q2"}},
+		},
+		EstadoInicial: "q0",
+		EstadosFinais: []string{"q2"},
+	}}
+	vazio, _, err := semCiclo.vazio()
+	if err != nil || !vazio {
+		t.Errorf("vazio() = (%v, %v), want (true, nil)", vazio, err)
+	}
+
+	// Depois de "ba", o ciclo q2 -b-> q3 -a-> q2 passa pelo final
+	comCiclo := AutomatoBuchi{AutomatoFinito{
+		Estados:  []string{"q0", "q1", "q2", "q3"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"q0": {'b': {"q1"}},
+			"q1": {'a': {"q2"}},
+			"q2": {'b': {"q3"}},
+			"q3": {'a': {"q2"}},
+		},
+		EstadoInicial: "q0",
+		EstadosFinais: []string{"q3"},
+	}}
+	vazio, laco, err := comCiclo.vazio()
+	if err != nil || vazio {
+		t.Fatalf("vazio() = (%v, %v), want (false, nil)", vazio, err)
+	}
+	if aceito, _ := comCiclo.aceita(laco); !aceito {
+		t.Errorf("o laço testemunha %v não é aceito", laco)
+	}
+	if laco.String() != "bab(ab)^ω" {
+		t.Errorf("laço = %v, want bab(ab)^ω", laco)
+	}
+
+	comEpsilon := AutomatoBuchi{AutomatoFinito{
+		Estados:           []string{"q0"},
+		TransicoesEpsilon: map[string][]string{"q0": {"q0"}},
+		EstadoInicial:     "q0",
+	}}
+	if _, _, err := comEpsilon.vazio(); err == nil {
+		t.Errorf("vazio() com transição épsilon deveria retornar erro")
+	}
+}
@@ -0,0 +1,489 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// TransicaoClasse leva ao destino com qualquer símbolo da classe.
+type TransicaoClasse struct {
+	Classe  ClasseSimbolos
+	Destino string
+}
+
+// AutomatoClasses é um autômato finito cujas transições são rotuladas por classes de símbolos em vez
+// de runas isoladas, para alfabetos grandes como todas as letras Unicode. As transições épsilon
+// ficam separadas, já que ε não é um símbolo de nenhuma classe. Outros guarda as transições por
+// "qualquer outro símbolo", cuja classe só é calculada no uso (ver transicoes).
+type AutomatoClasses struct {
+	Estados           []string
+	Transicoes        map[string][]TransicaoClasse
+	TransicoesEpsilon map[string][]string
+	Outros            map[string][]string
+	EstadoInicial     string
+	EstadosFinais     []string
+}
+
+func (AC *AutomatoClasses) adicionarEstado(estado string) {
+	AC.Estados = append(AC.Estados, estado)
+}
+
+// adicionarTransicao junta a classe à transição já existente entre os mesmos estados, se houver.
+func (AC *AutomatoClasses) adicionarTransicao(origem string, classe ClasseSimbolos, destino string) {
+	if AC.Transicoes == nil {
+		AC.Transicoes = make(map[string][]TransicaoClasse)
+	}
+	for i, transicao := range AC.Transicoes[origem] {
+		if transicao.Destino == destino {
+			AC.Transicoes[origem][i].Classe = transicao.Classe.uniao(classe)
+			return
+		}
+	}
+	AC.Transicoes[origem] = append(AC.Transicoes[origem], TransicaoClasse{classe, destino})
+}
+
+// adicionarTransicaoOutros liga origem a destino por todos os símbolos que não têm outra transição
+// a partir de origem ("qualquer outro símbolo"), inclusive as adicionadas depois.
+func (AC *AutomatoClasses) adicionarTransicaoOutros(origem, destino string) {
+	if AC.Outros == nil {
+		AC.Outros = make(map[string][]string)
+	}
+	AC.Outros[origem] = append(AC.Outros[origem], destino)
+}
+
+// transicoes retorna as transições que saem de origem, com as de "qualquer outro símbolo" resolvidas
+// como o complemento das demais classes no momento da chamada.
+func (AC *AutomatoClasses) transicoes(origem string) []TransicaoClasse {
+	if len(AC.Outros[origem]) == 0 {
+		return AC.Transicoes[origem]
+	}
+	outros := classeQualquer()
+	for _, transicao := range AC.Transicoes[origem] {
+		outros = outros.diferenca(transicao.Classe)
+	}
+	transicoes := slices.Clone(AC.Transicoes[origem])
+	if !outros.vazia() {
+		for _, destino := range AC.Outros[origem] {
+			transicoes = append(transicoes, TransicaoClasse{outros, destino})
+		}
+	}
+	return transicoes
+}
+
+func (AC *AutomatoClasses) adicionarTransicaoEpsilon(origem, destino string) {
+	if AC.TransicoesEpsilon == nil {
+		AC.TransicoesEpsilon = make(map[string][]string)
+	}
+	AC.TransicoesEpsilon[origem] = append(AC.TransicoesEpsilon[origem], destino)
+}
+
+func (AC *AutomatoClasses) adicionarEstadoInicial(estado string) {
+	AC.EstadoInicial = estado
+}
+
+func (AC *AutomatoClasses) adicionarEstadoFinal(estado string) {
+	AC.EstadosFinais = append(AC.EstadosFinais, estado)
+}
+
+// fecho retorna os estados alcançáveis por transições épsilon, na ordem de Estados.
+func (AC *AutomatoClasses) fecho(estados []string) []string {
+	alcancados := make(map[string]bool)
+	pilha := slices.Clone(estados)
+	for _, estado := range estados {
+		alcancados[estado] = true
+	}
+	for len(pilha) > 0 {
+		estado := pilha[len(pilha)-1]
+		pilha = pilha[:len(pilha)-1]
+		for _, destino := range AC.TransicoesEpsilon[estado] {
+			if !alcancados[destino] {
+				alcancados[destino] = true
+				pilha = append(pilha, destino)
+			}
+		}
+	}
+	var resultado []string
+	for _, estado := range AC.Estados {
+		if alcancados[estado] {
+			resultado = append(resultado, estado)
+		}
+	}
+	return resultado
+}
+
+// aceita simula o autômato sobre a cadeia, mantendo o conjunto de estados ativos.
+func (AC *AutomatoClasses) aceita(cadeia string) bool {
+	atuais := AC.fecho([]string{AC.EstadoInicial})
+	for _, simbolo := range cadeia {
+		var proximos []string
+		for _, estado := range atuais {
+			for _, transicao := range AC.transicoes(estado) {
+				if transicao.Classe.contem(simbolo) && !slices.Contains(proximos, transicao.Destino) {
+					proximos = append(proximos, transicao.Destino)
+				}
+			}
+		}
+		atuais = AC.fecho(proximos)
+	}
+	return slices.ContainsFunc(atuais, func(estado string) bool { return slices.Contains(AC.EstadosFinais, estado) })
+}
+
+// determinizar aplica a construção de subconjuntos. Em cada subconjunto, as classes das transições
+// que saem dele são particionadas em átomos disjuntos; átomos que levam ao mesmo subconjunto são
+// reunidos numa única transição. Como em AutomatoFinito.determinizar, o resultado pode ser parcial.
+func (AC *AutomatoClasses) determinizar() AutomatoClasses {
+	AFD := AutomatoClasses{}
+	inicial := AC.fecho([]string{AC.EstadoInicial})
+	AFD.adicionarEstado(nomeConjunto(inicial))
+	AFD.adicionarEstadoInicial(nomeConjunto(inicial))
+
+	fila := [][]string{inicial}
+	for len(fila) > 0 {
+		conjunto := fila[0]
+		fila = fila[1:]
+		nome := nomeConjunto(conjunto)
+		if slices.ContainsFunc(conjunto, func(estado string) bool { return slices.Contains(AC.EstadosFinais, estado) }) {
+			AFD.adicionarEstadoFinal(nome)
+		}
+
+		var transicoes []TransicaoClasse
+		for _, estado := range conjunto {
+			transicoes = append(transicoes, AC.transicoes(estado)...)
+		}
+		classes := make([]ClasseSimbolos, len(transicoes))
+		for i, transicao := range transicoes {
+			classes[i] = transicao.Classe
+		}
+		atomos, pertence := particionar(classes)
+		for k, atomo := range atomos {
+			var destinos []string
+			for _, i := range pertence[k] {
+				destinos = append(destinos, transicoes[i].Destino)
+			}
+			destinos = AC.fecho(destinos)
+			nomeDestino := nomeConjunto(destinos)
+			if !slices.Contains(AFD.Estados, nomeDestino) {
+				AFD.adicionarEstado(nomeDestino)
+				fila = append(fila, destinos)
+			}
+			AFD.adicionarTransicao(nome, atomo, nomeDestino)
+		}
+	}
+	return AFD
+}
+
+// ehDeterministico indica se não há transições épsilon nem classes sobrepostas saindo de um estado.
+func (AC *AutomatoClasses) ehDeterministico() bool {
+	for _, destinos := range AC.TransicoesEpsilon {
+		if len(destinos) > 0 {
+			return false
+		}
+	}
+	origens := slices.Collect(maps.Keys(AC.Transicoes))
+	for origem := range AC.Outros {
+		origens = append(origens, origem)
+	}
+	for _, origem := range origens {
+		transicoes := AC.transicoes(origem)
+		for i := range transicoes {
+			for j := i + 1; j < len(transicoes); j++ {
+				if !transicoes[i].Classe.intersecao(transicoes[j].Classe).vazia() {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// removerInalcancaveis retorna uma cópia só com os estados alcançáveis a partir do inicial, na ordem
+// de Estados. A determinização já só gera subconjuntos alcançáveis; um AFD de entrada, não.
+func (AC *AutomatoClasses) removerInalcancaveis() AutomatoClasses {
+	alcancados := map[string]bool{AC.EstadoInicial: true}
+	for fila := []string{AC.EstadoInicial}; len(fila) > 0; fila = fila[1:] {
+		for _, transicao := range AC.transicoes(fila[0]) {
+			if !alcancados[transicao.Destino] {
+				alcancados[transicao.Destino] = true
+				fila = append(fila, transicao.Destino)
+			}
+		}
+		for _, destino := range AC.TransicoesEpsilon[fila[0]] {
+			if !alcancados[destino] {
+				alcancados[destino] = true
+				fila = append(fila, destino)
+			}
+		}
+	}
+	resultado := AutomatoClasses{}
+	for _, estado := range AC.Estados {
+		if !alcancados[estado] {
+			continue
+		}
+		resultado.adicionarEstado(estado)
+		if slices.Contains(AC.EstadosFinais, estado) {
+			resultado.adicionarEstadoFinal(estado)
+		}
+		for _, transicao := range AC.transicoes(estado) {
+			resultado.adicionarTransicao(estado, transicao.Classe, transicao.Destino)
+		}
+		for _, destino := range AC.TransicoesEpsilon[estado] {
+			resultado.adicionarTransicaoEpsilon(estado, destino)
+		}
+	}
+	resultado.adicionarEstadoInicial(AC.EstadoInicial)
+	return resultado
+}
+
+// minimizar retorna o AFD mínimo (parcial: o estado morto e os estados equivalentes a ele são
+// omitidos). O refinamento de partições usa como alfabeto os átomos de todas as classes do AFD, e
+// não as runas. Cada estado do resultado recebe o nome do primeiro estado do seu bloco.
+func (AC *AutomatoClasses) minimizar() AutomatoClasses {
+	var AFD AutomatoClasses
+	if AC.ehDeterministico() {
+		AFD = AC.removerInalcancaveis()
+	} else {
+		AFD = AC.determinizar()
+	}
+	var classes []ClasseSimbolos
+	for _, estado := range AFD.Estados {
+		for _, transicao := range AFD.Transicoes[estado] {
+			classes = append(classes, transicao.Classe)
+		}
+	}
+	atomos, _ := particionar(classes)
+
+	// O estado morto implícito ("") entra no refinamento para que os estados equivalentes a ele
+	// sejam identificados e removidos
+	const morto = ""
+	estados := append([]string{morto}, AFD.Estados...)
+	destino := func(estado string, atomo ClasseSimbolos) string {
+		for _, transicao := range AFD.Transicoes[estado] {
+			if transicao.Classe.contem(atomo[0].De) {
+				return transicao.Destino
+			}
+		}
+		return morto
+	}
+	bloco := make(map[string]int)
+	for _, estado := range estados {
+		if slices.Contains(AFD.EstadosFinais, estado) {
+			bloco[estado] = 1
+		}
+	}
+	for numBlocos := 0; ; {
+		assinaturas := make(map[string]int)
+		novo := make(map[string]int)
+		for _, estado := range estados {
+			partes := []string{fmt.Sprint(bloco[estado])}
+			for _, atomo := range atomos {
+				partes = append(partes, fmt.Sprint(bloco[destino(estado, atomo)]))
+			}
+			assinatura := strings.Join(partes, ",")
+			if _, ok := assinaturas[assinatura]; !ok {
+				assinaturas[assinatura] = len(assinaturas)
+			}
+			novo[estado] = assinaturas[assinatura]
+		}
+		bloco = novo
+		if len(assinaturas) == numBlocos {
+			break
+		}
+		numBlocos = len(assinaturas)
+	}
+
+	representante := make(map[int]string)
+	for _, estado := range AFD.Estados {
+		if _, ok := representante[bloco[estado]]; !ok && bloco[estado] != bloco[morto] {
+			representante[bloco[estado]] = estado
+		}
+	}
+	minimo := AutomatoClasses{}
+	for _, estado := range AFD.Estados {
+		if representante[bloco[estado]] != estado {
+			continue
+		}
+		minimo.adicionarEstado(estado)
+		if slices.Contains(AFD.EstadosFinais, estado) {
+			minimo.adicionarEstadoFinal(estado)
+		}
+		for _, atomo := range atomos {
+			if alvo := destino(estado, atomo); bloco[alvo] != bloco[morto] {
+				minimo.adicionarTransicao(estado, atomo, representante[bloco[alvo]])
+			}
+		}
+	}
+	if bloco[AFD.EstadoInicial] != bloco[morto] {
+		minimo.adicionarEstadoInicial(representante[bloco[AFD.EstadoInicial]])
+	}
+	return minimo
+}
+
+// paraClasses converte um AutomatoFinito, com uma classe de um símbolo por transição.
+func paraClasses(AF *AutomatoFinito) AutomatoClasses {
+	AC := AutomatoClasses{}
+	for _, estado := range AF.Estados {
+		AC.adicionarEstado(estado)
+	}
+	for _, origem := range AF.Estados {
+		for _, simbolo := range AF.simbolosOrdenados() {
+			for _, destino := range AF.Transicoes[origem][simbolo] {
+				AC.adicionarTransicao(origem, classeRuna(simbolo), destino)
+			}
+		}
+		for _, destino := range AF.TransicoesEpsilon[origem] {
+			AC.adicionarTransicaoEpsilon(origem, destino)
+		}
+	}
+	AC.adicionarEstadoInicial(AF.EstadoInicial)
+	for _, final := range AF.EstadosFinais {
+		AC.adicionarEstadoFinal(final)
+	}
+	return AC
+}
+
+// String escreve o autômato no formato texto de lerDSLClasses, com as classes na sintaxe de lerClasse.
+func (AC AutomatoClasses) String() string {
+	var sb strings.Builder
+	nomes := func(estados []string) string {
+		codificados := make([]string, len(estados))
+		for i, estado := range estados {
+			codificados[i] = codificarEstadoDSL(estado)
+		}
+		return strings.Join(codificados, " ")
+	}
+	if len(AC.Estados) > 0 {
+		fmt.Fprintf(&sb, "states %s\n", nomes(AC.Estados))
+	}
+	if AC.EstadoInicial != "" {
+		fmt.Fprintf(&sb, "start %s\n", codificarEstadoDSL(AC.EstadoInicial))
+	}
+	if len(AC.EstadosFinais) > 0 {
+		fmt.Fprintf(&sb, "final %s\n", nomes(AC.EstadosFinais))
+	}
+	for _, origem := range AC.Estados {
+		for _, transicao := range AC.Transicoes[origem] {
+			fmt.Fprintf(&sb, "%s -%s-> %s\n", codificarEstadoDSL(origem), transicao.Classe, codificarEstadoDSL(transicao.Destino))
+		}
+		if len(AC.Outros[origem]) > 0 {
+			fmt.Fprintf(&sb, "%s -outros-> %s\n", codificarEstadoDSL(origem), nomes(AC.Outros[origem]))
+		}
+		if len(AC.TransicoesEpsilon[origem]) > 0 {
+			fmt.Fprintf(&sb, "%s -ε-> %s\n", codificarEstadoDSL(origem), nomes(AC.TransicoesEpsilon[origem]))
+		}
+	}
+	return sb.String()
+}
+
+// lerDSLClasses interpreta o formato texto de autômatos com classes (.afc). É o formato de lerDSL
+// sem a linha alphabet (o alfabeto é o de todas as runas), com um rótulo por aresta:
+//
+//	start q0
+//	final q1
+//	q0 -[a-z_]-> q1       (uma classe na sintaxe de lerClasse)
+//	q1 -\w-> q1
+//	q1 -outros-> erro     (qualquer símbolo sem outra transição a partir de q1)
+//	q1 -ε-> q0            (ε ou eps para épsilon)
+func lerDSLClasses(texto string) (AutomatoClasses, error) {
+	AC := AutomatoClasses{}
+	linhaInicial := 0
+
+	for i, linha := range strings.Split(texto, "\n") {
+		numero := i + 1
+		erro := func(coluna int, formato string, args ...any) error {
+			return &ErroDSL{numero, coluna, fmt.Sprintf(formato, args...)}
+		}
+		nome := func(token tokenDSL) (string, error) {
+			estado, err := decodificarCadeia(token.texto)
+			if err != nil {
+				return "", erro(token.coluna, "%v", err)
+			}
+			if estado == "" {
+				return "", erro(token.coluna, "nome de estado vazio")
+			}
+			if !slices.Contains(AC.Estados, estado) {
+				AC.adicionarEstado(estado)
+			}
+			return estado, nil
+		}
+
+		tokens := separarTokens(linha)
+		if len(tokens) == 0 {
+			continue
+		}
+		switch tokens[0].texto {
+		case "states", "final":
+			if len(tokens) == 1 {
+				return AutomatoClasses{}, erro(tokens[0].coluna, "%s precisa de ao menos um estado", tokens[0].texto)
+			}
+			for _, token := range tokens[1:] {
+				estado, err := nome(token)
+				if err != nil {
+					return AutomatoClasses{}, err
+				}
+				if tokens[0].texto == "final" && !slices.Contains(AC.EstadosFinais, estado) {
+					AC.adicionarEstadoFinal(estado)
+				}
+			}
+		case "start":
+			if len(tokens) != 2 {
+				return AutomatoClasses{}, erro(tokens[0].coluna, "start precisa de exatamente um estado")
+			}
+			if linhaInicial != 0 {
+				return AutomatoClasses{}, erro(tokens[0].coluna, "estado inicial já definido na linha %d", linhaInicial)
+			}
+			estado, err := nome(tokens[1])
+			if err != nil {
+				return AutomatoClasses{}, err
+			}
+			AC.adicionarEstadoInicial(estado)
+			linhaInicial = numero
+		default:
+			if len(tokens) < 3 {
+				return AutomatoClasses{}, erro(tokens[0].coluna, "esperado \"ORIGEM -CLASSE-> DESTINO\" ou uma declaração (states, start, final)")
+			}
+			aresta := tokens[1]
+			if !strings.HasPrefix(aresta.texto, "-") || !strings.HasSuffix(aresta.texto, "->") || len(aresta.texto) < 4 {
+				return AutomatoClasses{}, erro(aresta.coluna, "esperado uma aresta como -[a-z]-> ou -outros->, encontrado %q", aresta.texto)
+			}
+			rotulo := strings.TrimSuffix(aresta.texto[1:], "->")
+			epsilon, outros := rotulo == "ε" || rotulo == "eps", rotulo == "outros"
+			var classe ClasseSimbolos
+			if !epsilon && !outros {
+				var err error
+				if classe, err = lerClasse(rotulo); err != nil {
+					return AutomatoClasses{}, erro(aresta.coluna+1, "%v", err)
+				}
+			}
+
+			origem, err := nome(tokens[0])
+			if err != nil {
+				return AutomatoClasses{}, err
+			}
+			for _, token := range tokens[2:] {
+				destino, err := nome(token)
+				if err != nil {
+					return AutomatoClasses{}, err
+				}
+				switch {
+				case epsilon:
+					if !slices.Contains(AC.TransicoesEpsilon[origem], destino) {
+						AC.adicionarTransicaoEpsilon(origem, destino)
+					}
+				case outros:
+					if !slices.Contains(AC.Outros[origem], destino) {
+						AC.adicionarTransicaoOutros(origem, destino)
+					}
+				default:
+					AC.adicionarTransicao(origem, classe, destino)
+				}
+			}
+		}
+	}
+
+	if linhaInicial == 0 {
+		return AutomatoClasses{}, fmt.Errorf("falta a linha \"start\" com o estado inicial")
+	}
+	return AC, nil
+}
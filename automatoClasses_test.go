@@ -0,0 +1,214 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func classe(t *testing.T, texto string) ClasseSimbolos {
+	t.Helper()
+	C, err := lerClasse(texto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return C
+}
+
+// automatoIdentificador aceita identificadores Unicode (letra seguida de letras, dígitos ou '_') ou
+// números inteiros, com classes que se sobrepõem e uma transição épsilon.
+func automatoIdentificador(t *testing.T) AutomatoClasses {
+	AC := AutomatoClasses{}
+	for _, estado := range []string{"q0", "id", "num", "sinal"} {
+		AC.adicionarEstado(estado)
+	}
+	AC.adicionarEstadoInicial("q0")
+	AC.adicionarTransicao("q0", classe(t, `\p{L}`), "id")
+	AC.adicionarTransicao("id", classe(t, `[\p{L}\d_]`), "id")
+	AC.adicionarTransicao("q0", classe(t, `[-+]`), "sinal")
+	AC.adicionarTransicaoEpsilon("q0", "sinal")
+	AC.adicionarTransicao("sinal", classe(t, `\d`), "num")
+	AC.adicionarTransicao("num", classe(t, `\d`), "num")
+	AC.adicionarEstadoFinal("id")
+	AC.adicionarEstadoFinal("num")
+	return AC
+}
+
+var cadeiasIdentificador = []struct {
+	cadeia   string
+	expected bool
+}{
+	{"x", true}, {"ação_2", true}, {"λx1", true}, {"中文", true}, {"42", true}, {"-7", true},
+	{"", false}, {"-", false}, {"2x", false}, {"x-", false}, {"+a", false}, {"_a", false},
+}
+
+func TestAutomatoClassesAceita(t *testing.T) {
+	AC := automatoIdentificador(t)
+	for _, tt := range cadeiasIdentificador {
+		if got := AC.aceita(tt.cadeia); got != tt.expected {
+			t.Errorf("aceita(%q) = %v, want %v", tt.cadeia, got, tt.expected)
+		}
+	}
+}
+
+func TestAutomatoClassesDeterminizarMinimizar(t *testing.T) {
+	AC := automatoIdentificador(t)
+	// Estados redundantes: id2 equivale a id e morto nunca alcança um final
+	AC.adicionarEstado("id2")
+	AC.adicionarEstado("morto")
+	AC.Transicoes["id"] = nil
+	AC.adicionarTransicao("id", classe(t, `\p{L}`), "id2")
+	AC.adicionarTransicao("id", classe(t, `[\d_]`), "id")
+	AC.adicionarTransicao("id2", classe(t, `[\p{L}\d_]`), "id")
+	AC.adicionarEstadoFinal("id2")
+	AC.adicionarTransicaoOutros("num", "morto")
+	AC.adicionarTransicao("morto", classeQualquer(), "morto")
+
+	AFD := AC.determinizar()
+	if !AFD.ehDeterministico() {
+		t.Fatalf("determinizar() não é determinístico:\n%s", AFD)
+	}
+	minimo := AC.minimizar()
+	if len(minimo.Estados) != 4 {
+		t.Errorf("minimizar() tem %d estados, want 4:\n%s", len(minimo.Estados), minimo)
+	}
+	for _, tt := range cadeiasIdentificador {
+		if got := AFD.aceita(tt.cadeia); got != tt.expected {
+			t.Errorf("determinizar().aceita(%q) = %v, want %v", tt.cadeia, got, tt.expected)
+		}
+		if got := minimo.aceita(tt.cadeia); got != tt.expected {
+			t.Errorf("minimizar().aceita(%q) = %v, want %v", tt.cadeia, got, tt.expected)
+		}
+	}
+}
+
+func TestAutomatoClassesMinimizarInalcancavel(t *testing.T) {
+	// Já determinístico: x não é alcançável, embora não seja equivalente a nenhum outro estado
+	AC := AutomatoClasses{}
+	for _, estado := range []string{"s", "f", "x"} {
+		AC.adicionarEstado(estado)
+	}
+	AC.adicionarTransicao("s", classe(t, "a"), "f")
+	AC.adicionarTransicao("x", classe(t, "b"), "x")
+	AC.adicionarEstadoInicial("s")
+	AC.adicionarEstadoFinal("f")
+	AC.adicionarEstadoFinal("x")
+
+	minimo := AC.minimizar()
+	if !slices.Equal(minimo.Estados, []string{"s", "f"}) {
+		t.Errorf("minimizar().Estados = %v, want [s f]:\n%s", minimo.Estados, minimo)
+	}
+}
+
+func TestParaClasses(t *testing.T) {
+	AC := paraClasses(&automatoTabela)
+	AF := automatoTabela.clonar()
+	for _, cadeia := range []string{"", "ab", "abab", "aab", "ba", "abb"} {
+		AF.adicionarCadeia(cadeia)
+		if got, expected := AC.aceita(cadeia), AF.funcionamento(); got != expected {
+			t.Errorf("paraClasses().aceita(%q) = %v, want %v", cadeia, got, expected)
+		}
+	}
+	if minimo := AC.minimizar(); len(minimo.Estados) != 3 {
+		t.Errorf("minimizar() tem %d estados, want 3:\n%s", len(minimo.Estados), minimo)
+	}
+}
+
+func TestAutomatoClassesOutrosOrdem(t *testing.T) {
+	// A transição por "qualquer outro símbolo" exclui também as classes adicionadas depois dela
+	for _, antes := range []bool{true, false} {
+		AC := AutomatoClasses{}
+		AC.adicionarEstado("s")
+		AC.adicionarEstado("letra")
+		AC.adicionarEstado("outro")
+		AC.adicionarEstadoInicial("s")
+		AC.adicionarEstadoFinal("letra")
+		if antes {
+			AC.adicionarTransicaoOutros("s", "outro")
+		}
+		AC.adicionarTransicao("s", classe(t, `\p{L}`), "letra")
+		if !antes {
+			AC.adicionarTransicaoOutros("s", "outro")
+		}
+		if !AC.ehDeterministico() {
+			t.Errorf("antes = %v: ehDeterministico() = false, want true", antes)
+		}
+		for cadeia, expected := range map[string]bool{"a": true, "é": true, "1": false, " ": false} {
+			if got := AC.aceita(cadeia); got != expected {
+				t.Errorf("antes = %v: aceita(%q) = %v, want %v", antes, cadeia, got, expected)
+			}
+		}
+	}
+}
+
+func TestLerDSLClasses(t *testing.T) {
+	texto := `# identificadores ou números, com qualquer outro símbolo levando ao erro
+start q0
+final id num
+q0 -\p{L}-> id
+id -[\p{L}\d_]-> id
+q0 -[-+]-> sinal
+q0 -ε-> sinal
+sinal -\d-> num
+num -\d-> num
+num -outros-> erro
+erro -.-> erro
+`
+	AC, err := lerDSLClasses(texto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(AC.Estados, []string{"q0", "id", "num", "sinal", "erro"}) {
+		t.Errorf("Estados = %v", AC.Estados)
+	}
+	for _, tt := range cadeiasIdentificador {
+		if got := AC.aceita(tt.cadeia); got != tt.expected {
+			t.Errorf("aceita(%q) = %v, want %v", tt.cadeia, got, tt.expected)
+		}
+	}
+
+	// String escreve no mesmo formato, com nomes que precisam de escape
+	AC.adicionarEstado("start")
+	AC.adicionarEstado("q 1")
+	AC.adicionarTransicao("erro", classe(t, `[ #]`), "q 1")
+	AC.adicionarTransicaoEpsilon("q 1", "start")
+	relido, err := lerDSLClasses(AC.String())
+	if err != nil {
+		t.Fatalf("lerDSLClasses(String()) erro: %v\n%s", err, AC)
+	}
+	if relido.String() != AC.String() {
+		t.Errorf("ida e volta:\n%s\nwant:\n%s", relido, AC)
+	}
+}
+
+func TestLerDSLClassesErros(t *testing.T) {
+	tests := []struct {
+		name   string
+		texto  string
+		linha  int
+		coluna int
+		trecho string
+	}{
+		{"aresta malformada", "start q0\nq0 [a-z] q1", 2, 4, "esperado uma aresta"},
+		{"classe inválida", "start q0\nq0 -[z-a]-> q1", 2, 5, "classe \"[z-a]\" inválida"},
+		{"dois iniciais", "start q0\nstart q1", 2, 1, "já definido na linha 1"},
+		{"linha incompleta", "start q0\nq0 -outros->", 2, 1, "esperado \"ORIGEM"},
+		{"sem alfabeto", "alphabet a", 1, 1, "esperado \"ORIGEM"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := lerDSLClasses(tt.texto)
+			var erroDSL *ErroDSL
+			if !errors.As(err, &erroDSL) {
+				t.Fatalf("lerDSLClasses() erro = %v, want *ErroDSL", err)
+			}
+			if erroDSL.Linha != tt.linha || erroDSL.Coluna != tt.coluna || !strings.Contains(erroDSL.Mensagem, tt.trecho) {
+				t.Errorf("lerDSLClasses() erro = %v, want linha %d, coluna %d contendo %q", err, tt.linha, tt.coluna, tt.trecho)
+			}
+		})
+	}
+	if _, err := lerDSLClasses("q0 -a-> q1"); err == nil || !strings.Contains(err.Error(), "falta a linha \"start\"") {
+		t.Errorf("lerDSLClasses() sem start: erro = %v", err)
+	}
+}
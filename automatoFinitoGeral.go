@@ -2,38 +2,55 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"slices"
+	"strings"
 )
 
-type AutomatoFinito struct {
-	Estados       []string
-	Alfabeto      []rune                       // vetor de caracters (rune's)
-	Transicoes    map[string]map[rune][]string // estadoOrigem: [símbolo: [estadoDestino]]
-	EstadoInicial string
-	EstadosFinais []string
-	Cadeia        []rune // vetor de caracters (rune's)
+// Automato é um autômato finito (AFD ou AFN) sobre símbolos de qualquer tipo comparável: runas,
+// bytes, tokens como "IF" e "ID" ou tipos próprios. As transições épsilon ficam separadas das
+// transições por símbolo, de forma que qualquer valor de S, inclusive 'ε', pode ser um símbolo.
+type Automato[S comparable] struct {
+	Estados           []string
+	Alfabeto          []S
+	Transicoes        map[string]map[S][]string // estadoOrigem: [símbolo: [estadoDestino]]
+	TransicoesEpsilon map[string][]string       // estadoOrigem: [estadoDestino]
+	EstadoInicial     string
+	EstadosFinais     []string
+	Cadeia            []S
 }
 
-func (AF *AutomatoFinito) adicionarEstado(estado string) {
+// AutomatoFinito é o autômato sobre caracteres (rune's), usado pelo menu, pelo console e pelos
+// formatos de arquivo.
+type AutomatoFinito = Automato[rune]
+
+func (AF *Automato[S]) adicionarEstado(estado string) {
 	AF.Estados = append(AF.Estados, estado)
 }
 
-func (AF *AutomatoFinito) adicionarAlfabeto(simbolo rune) {
+func (AF *Automato[S]) adicionarAlfabeto(simbolo S) {
 	AF.Alfabeto = append(AF.Alfabeto, simbolo)
 }
 
-func (AF *AutomatoFinito) adicionarTransicao(estadoOrigem string, simbolo rune, estadoDestino string) {
+func (AF *Automato[S]) adicionarTransicao(estadoOrigem string, simbolo S, estadoDestino string) {
 	if AF.Transicoes == nil {
-		AF.Transicoes = make(map[string]map[rune][]string)
+		AF.Transicoes = make(map[string]map[S][]string)
 	}
 	if AF.Transicoes[estadoOrigem] == nil {
-		AF.Transicoes[estadoOrigem] = make(map[rune][]string)
+		AF.Transicoes[estadoOrigem] = make(map[S][]string)
 	}
 	AF.Transicoes[estadoOrigem][simbolo] = append(AF.Transicoes[estadoOrigem][simbolo], estadoDestino)
 }
 
+func (AF *Automato[S]) adicionarTransicaoEpsilon(estadoOrigem string, estadoDestino string) {
+	if AF.TransicoesEpsilon == nil {
+		AF.TransicoesEpsilon = make(map[string][]string)
+	}
+	AF.TransicoesEpsilon[estadoOrigem] = append(AF.TransicoesEpsilon[estadoOrigem], estadoDestino)
+}
+
 // epsilonClosure retorna o conjunto de estados alcançáveis a partir de um conjunto de estados, seguindo apenas transições épsilon.
-func (AF *AutomatoFinito) epsilonClosure(estados []string) []string {
+func (AF *Automato[S]) epsilonClosure(estados []string) []string {
 	closure := make(map[string]bool)
 	for _, estado := range estados {
 		closure[estado] = true
@@ -46,14 +63,10 @@ func (AF *AutomatoFinito) epsilonClosure(estados []string) []string {
 		estadoAtual := pilha[len(pilha)-1]
 		pilha = pilha[:len(pilha)-1]
 
-		if transicoesEstado, ok := AF.Transicoes[estadoAtual]; ok {
-			if destinosEpsilon, ok := transicoesEstado['ε']; ok {
-				for _, destino := range destinosEpsilon {
-					if !closure[destino] {
-						closure[destino] = true
-						pilha = append(pilha, destino)
-					}
-				}
+		for _, destino := range AF.TransicoesEpsilon[estadoAtual] {
+			if !closure[destino] {
+				closure[destino] = true
+				pilha = append(pilha, destino)
 			}
 		}
 	}
@@ -65,19 +78,36 @@ func (AF *AutomatoFinito) epsilonClosure(estados []string) []string {
 	return resultado
 }
 
-func (AF *AutomatoFinito) adicionarEstadoInicial(estadoInicial string) {
+func (AF *Automato[S]) adicionarEstadoInicial(estadoInicial string) {
 	AF.EstadoInicial = estadoInicial
 }
 
-func (AF *AutomatoFinito) adicionarEstadoFinal(estadoFinal string) {
+func (AF *Automato[S]) adicionarEstadoFinal(estadoFinal string) {
 	AF.EstadosFinais = append(AF.EstadosFinais, estadoFinal)
 }
 
-func (AF *AutomatoFinito) adicionarCadeia(cadeia string) {
-	AF.Cadeia = []rune(cadeia)
+// adicionarCadeia define a entrada a partir de um texto: um símbolo por caractere quando os
+// símbolos são runas ou bytes e um token por palavra quando são cadeias. Para outros tipos de
+// símbolo é um erro, e a entrada deve ser dada por adicionarEntrada.
+func (AF *Automato[S]) adicionarCadeia(cadeia string) error {
+	switch entrada := any(&AF.Cadeia).(type) {
+	case *[]rune:
+		*entrada = []rune(cadeia)
+	case *[]byte:
+		*entrada = []byte(cadeia)
+	case *[]string:
+		*entrada = strings.Fields(cadeia)
+	default:
+		return fmt.Errorf("símbolos do tipo %T não são lidos de texto; use adicionarEntrada", *new(S))
+	}
+	return nil
+}
+
+func (AF *Automato[S]) adicionarEntrada(simbolos ...S) {
+	AF.Cadeia = simbolos
 }
 
-func (AF *AutomatoFinito) funcionamento() bool {
+func (AF *Automato[S]) funcionamento() bool {
 	estadosAtuais := AF.epsilonClosure([]string{AF.EstadoInicial})
 
 	for _, simbolo := range AF.Cadeia {
@@ -161,7 +191,10 @@ func exemplo() {
 	}
 
 	for _, teste := range testes {
-		AFN.adicionarCadeia(teste.cadeia)
+		if err := AFN.adicionarCadeia(teste.cadeia); err != nil {
+			fmt.Printf("Erro: %v.\n", err)
+			continue
+		}
 		resultado := AFN.funcionamento()
 		fmt.Printf("Cadeia \"%s\" -> ", teste.cadeia)
 		if resultado {
@@ -197,9 +230,8 @@ func exemplo() {
 
 	AFNepsilon.adicionarAlfabeto('a')
 	AFNepsilon.adicionarAlfabeto('b')
-	// Não adicionamos 'ε' ao alfabeto visível, mas usamos nas transições
 
-	AFNepsilon.adicionarTransicao("qe0", 'ε', "qe1") // Transição épsilon
+	AFNepsilon.adicionarTransicaoEpsilon("qe0", "qe1") // Transição épsilon
 	AFNepsilon.adicionarTransicao("qe1", 'a', "qe1")
 	AFNepsilon.adicionarTransicao("qe1", 'b', "qe2")
 
@@ -220,7 +252,10 @@ func exemplo() {
 	}
 
 	for _, teste := range testesEpsilon {
-		AFNepsilon.adicionarCadeia(teste.cadeia)
+		if err := AFNepsilon.adicionarCadeia(teste.cadeia); err != nil {
+			fmt.Printf("Erro: %v.\n", err)
+			continue
+		}
 		resultado := AFNepsilon.funcionamento()
 		fmt.Printf("Cadeia \"%s\" -> ", teste.cadeia)
 		if resultado {
@@ -236,13 +271,13 @@ func exemplo() {
 	}
 }
 
-func leituraEstados(AFUsuario *AutomatoFinito) {
+func leituraEstados(sessao *Sessao) {
+	AFUsuario := &sessao.Automato
 	fmt.Println("Digite os estados (um por vez). Digite \"fim\" para encerrar:")
 	for {
-		var estado string
 		fmt.Print("> ")
-		fmt.Scan(&estado)
-		if estado == "fim" {
+		estado, ok := lerEntrada("fim")
+		if !ok {
 			return
 		}
 		if estado == "" {
@@ -253,35 +288,38 @@ func leituraEstados(AFUsuario *AutomatoFinito) {
 			fmt.Printf("Erro: Estado '%s' já foi adicionado. Tente outro.\n", estado)
 			continue // Pede novo estado
 		}
-		AFUsuario.adicionarEstado(estado)
+		sessao.executar(Comando{"state", []string{estado}})
 		fmt.Printf("Estado '%s' adicionado.\n", estado)
 	}
 }
 
-func leituraEstadoInicial(AFUsuario *AutomatoFinito) bool {
+func leituraEstadoInicial(sessao *Sessao) bool {
+	AFUsuario := &sessao.Automato
 	fmt.Print("Digite o estado inicial: ")
-	var estado string
-	fmt.Scan(&estado)
+	estado, ok := lerEntrada("")
+	if !ok {
+		return false
+	}
 	if !slices.Contains(AFUsuario.Estados, estado) {
 		fmt.Println("Erro: Estado inicial não está presente nos estados adicionados")
 		return false
 	}
-	AFUsuario.adicionarEstadoInicial(estado)
+	sessao.executar(Comando{"start", []string{estado}})
 	return true
 }
 
-func leituraAlfabeto(AFUsuario *AutomatoFinito) bool {
+func leituraAlfabeto(sessao *Sessao) bool {
+	AFUsuario := &sessao.Automato
 	fmt.Println("Digite o alfabeto (um símbolo por vez). Digite \"fim\" para encerrar:")
 	for {
-		var entrada string
 		fmt.Print("> ")
-		fmt.Scan(&entrada)
-		if entrada == "fim" {
+		entrada, ok := lerEntrada("fim")
+		if !ok {
 			return true
 		}
 		r := []rune(entrada)
 		if len(r) != 1 {
-			fmt.Println("Erro: insira exatamente um caractere.")
+			fmt.Println("Erro: insira exatamente um caractere (\\s para espaço, \\ε para o símbolo ε).")
 			continue // Pede novo símbolo
 		}
 		simbolo := r[0]
@@ -289,14 +327,15 @@ func leituraAlfabeto(AFUsuario *AutomatoFinito) bool {
 			fmt.Printf("Erro: Símbolo '%c' já foi adicionado ao alfabeto. Tente outro.\n", simbolo)
 			continue // Pede novo símbolo
 		}
-		AFUsuario.adicionarAlfabeto(simbolo)
+		sessao.executar(Comando{"symbol", []string{string(simbolo)}})
 		fmt.Printf("Símbolo '%c' adicionado ao alfabeto.\n", simbolo)
 	}
 }
 
 const epsilonRune = 'ε'
 
-func leituraTransicoes(AFUsuario *AutomatoFinito) bool {
+func leituraTransicoes(sessao *Sessao) bool {
+	AFUsuario := &sessao.Automato
 	fmt.Println("\n--- Adicionar Transições ---")
 	fmt.Println("Para cada transição, primeiro o estado de origem e o símbolo.")
 	fmt.Println("Depois, digite os estados de destino um por vez.")
@@ -304,10 +343,9 @@ func leituraTransicoes(AFUsuario *AutomatoFinito) bool {
 	fmt.Println("Digite \"fim\" como origem para encerrar a adição de todas as transições.")
 
 	for {
-		var origem string
 		fmt.Print("\nOrigem (ou \"fim\" para encerrar tudo): ")
-		fmt.Scan(&origem)
-		if origem == "fim" {
+		origem, ok := lerEntrada("fim")
+		if !ok {
 			return true
 		}
 
@@ -316,34 +354,30 @@ func leituraTransicoes(AFUsuario *AutomatoFinito) bool {
 			continue // Pede nova origem
 		}
 
-		var simboloStr string
-		fmt.Printf("Símbolo para %s (ou \"eps\"/\"epsilon\" para épsilon): ", origem)
-		fmt.Scan(&simboloStr)
+		fmt.Printf("Símbolo para %s (ou \"ε\", \"eps\" ou \"epsilon\" para épsilon; \\ε para o símbolo ε): ", origem)
+		simboloStr, ok := lerEntrada("")
+		if !ok {
+			return true
+		}
 
-		var simbolo rune
-		isEpsilon := false
-		if simboloStr == "eps" || simboloStr == "epsilon" {
-			simbolo = epsilonRune
-			isEpsilon = true
-		} else {
-			r := []rune(simboloStr)
-			if len(r) != 1 {
-				fmt.Println("Erro: símbolo deve ser um único caractere ou \"eps\"/\"epsilon\".")
-				continue // Pede nova origem
-			}
-			simbolo = r[0]
-			if !slices.Contains(AFUsuario.Alfabeto, simbolo) {
-				fmt.Printf("Erro: Símbolo '%c' não presente no alfabeto.\n", simbolo)
-				continue // Pede nova origem
-			}
+		simbolo, epsilon, err := simboloComando(simboloStr)
+		if err != nil {
+			fmt.Printf("Erro: %v.\n", err)
+			continue // Pede nova origem
+		}
+		rotulo := fmt.Sprintf("%q", simbolo)
+		if epsilon {
+			simboloStr, rotulo = "", string(epsilonRune)
+		} else if !slices.Contains(AFUsuario.Alfabeto, simbolo) {
+			fmt.Printf("Erro: Símbolo '%c' não presente no alfabeto.\n", simbolo)
+			continue // Pede nova origem
 		}
 
-		fmt.Printf("Adicionando destinos para (%s, %q):\n", origem, simbolo)
+		fmt.Printf("Adicionando destinos para (%s, %s):\n", origem, rotulo)
 		for {
-			var destino string
-			fmt.Printf("  Destino para %s,%q (ou \"fim\" para esta transição): ", origem, simbolo)
-			fmt.Scan(&destino)
-			if destino == "fim" {
+			fmt.Printf("  Destino para %s,%s (ou \"fim\" para esta transição): ", origem, rotulo)
+			destino, ok := lerEntrada("fim")
+			if !ok {
 				break // Finaliza destinos para esta transição (origem, simbolo)
 			}
 
@@ -352,20 +386,23 @@ func leituraTransicoes(AFUsuario *AutomatoFinito) bool {
 				continue // Pede novo destino para a mesma (origem, simbolo)
 			}
 
-			AFUsuario.adicionarTransicao(origem, simbolo, destino)
-			fmt.Printf("    Adicionado: %s --%q--> %s\n", origem, simbolo, destino)
+			if err := sessao.executar(Comando{"trans", []string{origem, simboloStr, destino}}); err != nil {
+				fmt.Printf("Erro: %v.\n", err)
+				continue // Pede novo destino para a mesma (origem, simbolo)
+			}
+			fmt.Printf("    Adicionado: %s --%s--> %s\n", origem, rotulo, destino)
 		}
 		fmt.Println("Próxima transição.")
 	}
 }
 
-func leituraEstadosFinais(AFUsuario *AutomatoFinito) bool {
+func leituraEstadosFinais(sessao *Sessao) bool {
+	AFUsuario := &sessao.Automato
 	fmt.Println("Digite os estados finais (um por vez). Digite \"fim\" para encerrar:")
 	for {
-		var estado string
 		fmt.Print("> ")
-		fmt.Scan(&estado)
-		if estado == "fim" {
+		estado, ok := lerEntrada("fim")
+		if !ok {
 			return true
 		}
 		if !slices.Contains(AFUsuario.Estados, estado) {
@@ -376,37 +413,50 @@ func leituraEstadosFinais(AFUsuario *AutomatoFinito) bool {
 			fmt.Printf("Erro: Estado '%s' já foi adicionado como final. Tente outro.\n", estado)
 			continue // Pede novo estado final
 		}
-		AFUsuario.adicionarEstadoFinal(estado)
+		sessao.executar(Comando{"final", []string{estado}})
 		fmt.Printf("Estado final '%s' adicionado.\n", estado)
 	}
 }
 
 func exibicaoAutomato(AFUsuario *AutomatoFinito) {
+	texto, err := formatarAutomato(AFUsuario)
+	if err != nil {
+		fmt.Printf("Erro: %v.\n", err)
+		return
+	}
 	fmt.Println("\nAutômato criado:")
-	fmt.Printf("Estado Inicial: %s\n", AFUsuario.EstadoInicial)
-	fmt.Printf("Estados: %v\n", AFUsuario.Estados)
-	fmt.Printf("Alfabeto: %q\n", AFUsuario.Alfabeto)
-	fmt.Println("Transições:")
-	for estado, m := range AFUsuario.Transicoes {
-		for simbolo, destinos := range m {
-			for _, destino := range destinos {
-				fmt.Printf("%s,%q --> %s\n", estado, simbolo, destino)
-			}
-		}
+	fmt.Print(texto)
+}
+
+// formatarAutomato descreve o autômato pela tabela de transições.
+func formatarAutomato(AFUsuario *AutomatoFinito) (string, error) {
+	T, err := tabelaTransicoes(AFUsuario)
+	if err != nil {
+		return "", err
 	}
-	fmt.Printf("Estados Finais: %v\n", AFUsuario.EstadosFinais)
+	return T.paraMarkdown() +
+		fmt.Sprintf("(%s estado inicial, %s estado final, %s sem transição)\n", marcadorInicial, marcadorFinal, celulaVazia), nil
 }
 
 func testeCadeiasUsuario(AFUsuario *AutomatoFinito) {
-	fmt.Println("Digite a cadeia para testar (ou \"sair\" para encerrar):")
+	fmt.Println("Digite a cadeia para testar (ou \"sair\" para encerrar).")
+	fmt.Println("Uma linha vazia ou \"ε\" testa a cadeia vazia; use \\s para espaço e \\ε para o símbolo ε.")
 	for {
-		var entrada string
 		fmt.Print("> ")
-		fmt.Scan(&entrada)
-		if entrada == "sair" {
+		linha, ok := lerLinha()
+		if !ok || linha == "sair" {
 			return
 		}
-		AFUsuario.adicionarCadeia(entrada)
+		// Só o terminador de linha é removido: espaços digitados fazem parte da cadeia
+		cadeia, err := decodificarCadeia(linha)
+		if err != nil {
+			fmt.Printf("Erro: %v.\n", err)
+			continue
+		}
+		if err := AFUsuario.adicionarCadeia(cadeia); err != nil {
+			fmt.Printf("Erro: %v.\n", err)
+			continue
+		}
 		if AFUsuario.funcionamento() {
 			fmt.Println("Cadeia aceita")
 		} else {
@@ -415,54 +465,92 @@ func testeCadeiasUsuario(AFUsuario *AutomatoFinito) {
 	}
 }
 
-func automatoUsuario() {
+func automatoUsuario(area *AreaTrabalho) {
 	fmt.Println("\n==== Crie seu autômato ====")
-	AFUsuario := AutomatoFinito{}
+	sessao := novaSessao(AutomatoFinito{})
 
-	leituraEstados(&AFUsuario)
-	if !leituraEstadoInicial(&AFUsuario) {
+	leituraEstados(sessao)
+	if !leituraEstadoInicial(sessao) {
+		return
+	}
+	if !leituraAlfabeto(sessao) {
 		return
 	}
-	if !leituraAlfabeto(&AFUsuario) {
+	if !leituraTransicoes(sessao) {
 		return
 	}
-	if !leituraTransicoes(&AFUsuario) {
+	if !leituraEstadosFinais(sessao) {
 		return
 	}
-	if !leituraEstadosFinais(&AFUsuario) {
+
+	exibicaoAutomato(&sessao.Automato)
+	if sessao.Automato.ehDeterministico() {
+		exibicaoTabelaDistinguibilidade(&sessao.Automato)
+	}
+	testeCadeiasUsuario(&sessao.Automato)
+	edicaoAutomato(sessao)
+	fmt.Printf("Autômato guardado na área de trabalho como '%s'.\n", area.guardarSessao("passo", sessao))
+}
+
+func scriptUsuario(area *AreaTrabalho) {
+	fmt.Print("Arquivo do script: ")
+	caminho, ok := lerEntrada("")
+	if !ok {
+		return
+	}
+	conteudo, err := os.ReadFile(caminho)
+	if err != nil {
+		fmt.Printf("Erro: %v.\n", err)
+		return
+	}
+	sessao, err := executarScript(string(conteudo))
+	if err != nil {
+		fmt.Printf("Erro em %s: %v.\n", caminho, err)
 		return
 	}
 
-	exibicaoAutomato(&AFUsuario)
-	testeCadeiasUsuario(&AFUsuario)
+	exibicaoAutomato(&sessao.Automato)
+	edicaoAutomato(sessao)
+	fmt.Printf("Autômato guardado na área de trabalho como '%s'.\n", area.guardarSessao("script", sessao))
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		os.Exit(executarComando(os.Args[1:], os.Stdout))
+	}
+
+	area := novaAreaTrabalho()
 	for {
 		fmt.Println("\nMenu Principal:")
 		fmt.Println("1. Rodar Exemplo Pré-definido")
-		fmt.Println("2. Criar Novo Autômato")
-		fmt.Println("3. Sair")
+		fmt.Println("2. Criar Novo Autômato (console de comandos)")
+		fmt.Println("3. Criar Novo Autômato (passo a passo)")
+		fmt.Println("4. Carregar Autômato de Script")
+		fmt.Println("5. Área de Trabalho (união, interseção, equivalência...)")
+		fmt.Println("6. Outros Modelos (pilha, Turing, ponderado, probabilístico, Moore/Mealy)")
+		fmt.Println("7. Sair")
 		fmt.Print("Escolha uma opção: ")
 
-		var escolha int
-		// Tentativa de ler o inteiro. Ignora entradas não numéricas por enquanto.
-		_, err := fmt.Scanln(&escolha)
-		if err != nil {
-			fmt.Println("Entrada inválida. Por favor, digite um número.")
-			// Limpar o buffer de entrada em caso de erro, para evitar loop infinito se a entrada inválida não for consumida.
-			// Esta é uma forma simples de tentar limpar. Pode não ser perfeita para todos os casos.
-			var temp string
-			fmt.Scanln(&temp) // Tenta consumir o resto da linha inválida.
-			continue
+		escolha, ok := lerOpcao()
+		if !ok {
+			fmt.Println("\nEncerrando o programa.")
+			return
 		}
 
 		switch escolha {
 		case 1:
 			exemplo()
 		case 2:
-			automatoUsuario()
+			console(area)
 		case 3:
+			automatoUsuario(area)
+		case 4:
+			scriptUsuario(area)
+		case 5:
+			menuAreaTrabalho(area)
+		case 6:
+			menuModelos()
+		case 7:
 			fmt.Println("Encerrando o programa.")
 			return // Sai da função main e, portanto, do programa
 		default:
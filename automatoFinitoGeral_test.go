@@ -1,7 +1,6 @@
 package main
 
 import (
-	"slices"
 	"testing"
 )
 
@@ -54,9 +53,9 @@ func TestEpsilonClosure(t *testing.T) {
 			name: "Simple chain q0-e->q1-e->q2",
 			af: AutomatoFinito{
 				Estados: []string{"q0", "q1", "q2"},
-				Transicoes: map[string]map[rune][]string{
-					"q0": {'ε': {"q1"}},
-					"q1": {'ε': {"q2"}},
+				TransicoesEpsilon: map[string][]string{
+					"q0": {"q1"},
+					"q1": {"q2"},
 				},
 			},
 			initialStates:  []string{"q0"},
@@ -66,9 +65,9 @@ func TestEpsilonClosure(t *testing.T) {
 			name: "Simple chain starting from middle q0-e->q1-e->q2, start q1",
 			af: AutomatoFinito{
 				Estados: []string{"q0", "q1", "q2"},
-				Transicoes: map[string]map[rune][]string{
-					"q0": {'ε': {"q1"}},
-					"q1": {'ε': {"q2"}},
+				TransicoesEpsilon: map[string][]string{
+					"q0": {"q1"},
+					"q1": {"q2"},
 				},
 			},
 			initialStates:  []string{"q1"},
@@ -78,8 +77,8 @@ func TestEpsilonClosure(t *testing.T) {
 			name: "Branching epsilon transitions q0-e->q1, q0-e->q2",
 			af: AutomatoFinito{
 				Estados: []string{"q0", "q1", "q2"},
-				Transicoes: map[string]map[rune][]string{
-					"q0": {'ε': {"q1", "q2"}},
+				TransicoesEpsilon: map[string][]string{
+					"q0": {"q1", "q2"},
 				},
 			},
 			initialStates:  []string{"q0"},
@@ -89,9 +88,9 @@ func TestEpsilonClosure(t *testing.T) {
 			name: "Cycles q0-e->q1, q1-e->q0",
 			af: AutomatoFinito{
 				Estados: []string{"q0", "q1"},
-				Transicoes: map[string]map[rune][]string{
-					"q0": {'ε': {"q1"}},
-					"q1": {'ε': {"q0"}},
+				TransicoesEpsilon: map[string][]string{
+					"q0": {"q1"},
+					"q1": {"q0"},
 				},
 			},
 			initialStates:  []string{"q0"},
@@ -101,9 +100,9 @@ func TestEpsilonClosure(t *testing.T) {
 			name: "Cycles with a tail q0-e->q1, q1-e->q0, q1-e->q2",
 			af: AutomatoFinito{
 				Estados: []string{"q0", "q1", "q2"},
-				Transicoes: map[string]map[rune][]string{
-					"q0": {'ε': {"q1"}},
-					"q1": {'ε': {"q0", "q2"}},
+				TransicoesEpsilon: map[string][]string{
+					"q0": {"q1"},
+					"q1": {"q0", "q2"},
 				},
 			},
 			initialStates:  []string{"q0"},
@@ -113,8 +112,8 @@ func TestEpsilonClosure(t *testing.T) {
 			name: "Disconnected components with epsilon, start q0 (reaches q1), q2 no eps",
 			af: AutomatoFinito{
 				Estados: []string{"q0", "q1", "q2"},
-				Transicoes: map[string]map[rune][]string{
-					"q0": {'ε': {"q1"}},
+				TransicoesEpsilon: map[string][]string{
+					"q0": {"q1"},
 				},
 			},
 			initialStates:  []string{"q0"},
@@ -124,8 +123,8 @@ func TestEpsilonClosure(t *testing.T) {
 			name: "Disconnected components, start q2 (no eps from q2)",
 			af: AutomatoFinito{
 				Estados: []string{"q0", "q1", "q2"},
-				Transicoes: map[string]map[rune][]string{
-					"q0": {'ε': {"q1"}},
+				TransicoesEpsilon: map[string][]string{
+					"q0": {"q1"},
 				},
 			},
 			initialStates:  []string{"q2"},
@@ -135,10 +134,10 @@ func TestEpsilonClosure(t *testing.T) {
 			name: "Multiple initial states with shared epsilon transitions",
 			af: AutomatoFinito{
 				Estados: []string{"q0", "q1", "q2", "q3"},
-				Transicoes: map[string]map[rune][]string{
-					"q0": {'ε': {"q2"}},
-					"q1": {'ε': {"q2"}},
-					"q2": {'ε': {"q3"}},
+				TransicoesEpsilon: map[string][]string{
+					"q0": {"q2"},
+					"q1": {"q2"},
+					"q2": {"q3"},
 				},
 			},
 			initialStates:  []string{"q0", "q1"},
@@ -148,9 +147,9 @@ func TestEpsilonClosure(t *testing.T) {
 			name: "Initial states include a state reachable by epsilon from another initial",
 			af: AutomatoFinito{
 				Estados: []string{"q0", "q1", "q2"},
-				Transicoes: map[string]map[rune][]string{
-					"q0": {'ε': {"q1"}},
-					"q1": {'ε': {"q2"}},
+				TransicoesEpsilon: map[string][]string{
+					"q0": {"q1"},
+					"q1": {"q2"},
 				},
 			},
 			initialStates:  []string{"q0", "q1"},
@@ -160,8 +159,8 @@ func TestEpsilonClosure(t *testing.T) {
 			name: "No initial states",
 			af: AutomatoFinito{
 				Estados: []string{"q0", "q1"},
-				Transicoes: map[string]map[rune][]string{
-					"q0": {'ε': {"q1"}},
+				TransicoesEpsilon: map[string][]string{
+					"q0": {"q1"},
 				},
 			},
 			initialStates:  []string{},
@@ -171,8 +170,8 @@ func TestEpsilonClosure(t *testing.T) {
 			name: "Epsilon to non-existent state (should be handled gracefully by AF structure, closure just explores)",
 			af: AutomatoFinito{
 				Estados: []string{"q0"},
-				Transicoes: map[string]map[rune][]string{
-					"q0": {'ε': {"q1"}}, // q1 not in AF.Estados, but epsilonClosure should still work
+				TransicoesEpsilon: map[string][]string{
+					"q0": {"q1"}, // q1 not in AF.Estados, but epsilonClosure should still work
 				},
 			},
 			initialStates:  []string{"q0"},
@@ -229,13 +228,13 @@ func TestFuncionamentoNFA(t *testing.T) {
 		Estados:       []string{"q_start", "q_a_loop", "q_b_trans", "q_final"},
 		Alfabeto:      []rune{'a', 'b'},
 		Transicoes:    map[string]map[rune][]string{
-			"q_start":  {'ε': {"q_a_loop"}},
-			"q_a_loop": {
-				'a': {"q_a_loop"},
-				'ε': {"q_b_trans"},
-			},
+			"q_a_loop":  {'a': {"q_a_loop"}},
 			"q_b_trans": {'b': {"q_final"}},
 		},
+		TransicoesEpsilon: map[string][]string{
+			"q_start":  {"q_a_loop"},
+			"q_a_loop": {"q_b_trans"},
+		},
 		EstadoInicial: "q_start",
 		EstadosFinais: []string{"q_final"},
 	}
@@ -266,7 +265,7 @@ func TestFuncionamentoNFA(t *testing.T) {
 		{"NFA1_ab", &nfa1, "ab", true},
 		{"NFA1_aab", &nfa1, "aab", true},
 		{"NFA1_bab", &nfa1, "bab", true},
-		{"NFA1_cab", &nfa1, "cab", true}, // 'c' not in alphabet, handled by NFA logic (no transition)
+		{"NFA1_cab", &nfa1, "cab", false}, // 'c' not in alphabet, handled by NFA logic (no transition)
 		{"NFA1_b", &nfa1, "b", false},
 		{"NFA1_a", &nfa1, "a", false},
 		{"NFA1_acb_c_not_in_alphabet", &nfa1, "acb", false},
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// limitePassosPadrao é usado quando LimitePassos não é definido.
+const limitePassosPadrao = 10000
+
+// TransicaoPilha estende a transição (estado, símbolo) de AutomatoFinito com a operação na pilha.
+// As cadeias da pilha são escritas com o topo à esquerda: desempilhar "AB" exige A no topo e B logo
+// abaixo; empilhar "AB" deixa A no topo.
+type TransicaoPilha struct {
+	Desempilha string // vazio: a transição não consulta a pilha
+	Empilha    string
+	Destino    string
+}
+
+// ModoAceitacao define quando uma configuração com a entrada toda consumida é de aceitação.
+type ModoAceitacao int
+
+const (
+	AceitacaoEstadoFinal ModoAceitacao = iota
+	AceitacaoPilhaVazia
+)
+
+// AutomatoPilha é um autômato com pilha não determinístico. Como em AutomatoFinito, as transições
+// que não consomem entrada ficam em TransicoesEpsilon, e 'ε' em Transicoes é um símbolo comum.
+type AutomatoPilha struct {
+	Estados           []string
+	Alfabeto          []rune
+	AlfabetoPilha     []rune
+	Transicoes        map[string]map[rune][]TransicaoPilha // estadoOrigem: [símbolo: [transições]]
+	TransicoesEpsilon map[string][]TransicaoPilha          // estadoOrigem: [transições]
+	EstadoInicial     string
+	PilhaInicial      string // conteúdo inicial da pilha, topo à esquerda (ex.: "Z")
+	EstadosFinais     []string
+	Aceitacao         ModoAceitacao
+	LimitePassos      int // máximo de configurações exploradas; 0 usa limitePassosPadrao
+	Cadeia            []rune
+}
+
+// ConfiguracaoPilha é uma configuração instantânea: estado, entrada restante e pilha (topo à esquerda).
+type ConfiguracaoPilha struct {
+	Estado   string
+	Restante string
+	Pilha    string
+}
+
+// String escreve a entrada restante e a pilha com codificarCadeia: ε é a cadeia vazia e \ε o símbolo.
+func (C ConfiguracaoPilha) String() string {
+	return fmt.Sprintf("(%s, %s, %s)", C.Estado, codificarCadeia(C.Restante), codificarCadeia(C.Pilha))
+}
+
+// ResultadoPilha é o resultado de uma execução. Trace é a sequência de configurações da
+// computação de aceitação, vazia quando a cadeia não é aceita.
+type ResultadoPilha struct {
+	Aceita         bool
+	LimiteAtingido bool // a busca parou no limite de passos sem encontrar aceitação
+	Trace          []ConfiguracaoPilha
+}
+
+func (AP *AutomatoPilha) adicionarEstado(estado string) {
+	AP.Estados = append(AP.Estados, estado)
+}
+
+func (AP *AutomatoPilha) adicionarTransicao(estadoOrigem string, simbolo rune, desempilha string, empilha string, estadoDestino string) {
+	if AP.Transicoes == nil {
+		AP.Transicoes = make(map[string]map[rune][]TransicaoPilha)
+	}
+	if AP.Transicoes[estadoOrigem] == nil {
+		AP.Transicoes[estadoOrigem] = make(map[rune][]TransicaoPilha)
+	}
+	AP.Transicoes[estadoOrigem][simbolo] = append(AP.Transicoes[estadoOrigem][simbolo], TransicaoPilha{desempilha, empilha, estadoDestino})
+}
+
+func (AP *AutomatoPilha) adicionarTransicaoEpsilon(estadoOrigem string, desempilha string, empilha string, estadoDestino string) {
+	if AP.TransicoesEpsilon == nil {
+		AP.TransicoesEpsilon = make(map[string][]TransicaoPilha)
+	}
+	AP.TransicoesEpsilon[estadoOrigem] = append(AP.TransicoesEpsilon[estadoOrigem], TransicaoPilha{desempilha, empilha, estadoDestino})
+}
+
+func (AP *AutomatoPilha) adicionarEstadoInicial(estadoInicial string, pilhaInicial string) {
+	AP.EstadoInicial = estadoInicial
+	AP.PilhaInicial = pilhaInicial
+}
+
+func (AP *AutomatoPilha) adicionarEstadoFinal(estadoFinal string) {
+	AP.EstadosFinais = append(AP.EstadosFinais, estadoFinal)
+}
+
+func (AP *AutomatoPilha) adicionarCadeia(cadeia string) {
+	AP.Cadeia = []rune(cadeia)
+}
+
+// funcionamento explora as configurações em largura a partir da inicial, até encontrar uma de
+// aceitação ou atingir o limite de passos. Configurações repetidas não são exploradas de novo,
+// mas laços épsilon que fazem a pilha crescer sem limite só são interrompidos pelo limite.
+func (AP *AutomatoPilha) funcionamento() ResultadoPilha {
+	limite := AP.LimitePassos
+	if limite <= 0 {
+		limite = limitePassosPadrao
+	}
+
+	type no struct {
+		estado  string
+		posicao int
+		pilha   string
+		pai     int
+	}
+	chave := func(n no) string {
+		return fmt.Sprintf("%s\x00%d\x00%s", n.estado, n.posicao, n.pilha)
+	}
+
+	nos := []no{{AP.EstadoInicial, 0, AP.PilhaInicial, -1}}
+	visitados := map[string]bool{chave(nos[0]): true}
+	for i := 0; i < len(nos); i++ {
+		if i >= limite {
+			return ResultadoPilha{LimiteAtingido: true}
+		}
+		atual := nos[i]
+
+		if atual.posicao == len(AP.Cadeia) && AP.aceitaConfiguracao(atual.estado, atual.pilha) {
+			var trace []ConfiguracaoPilha
+			for j := i; j >= 0; j = nos[j].pai {
+				trace = append(trace, ConfiguracaoPilha{nos[j].estado, string(AP.Cadeia[nos[j].posicao:]), nos[j].pilha})
+			}
+			slices.Reverse(trace)
+			return ResultadoPilha{Aceita: true, Trace: trace}
+		}
+
+		for _, transicao := range AP.TransicoesEpsilon[atual.estado] {
+			if pilha, ok := aplicarPilha(atual.pilha, transicao); ok {
+				proximo := no{transicao.Destino, atual.posicao, pilha, i}
+				if !visitados[chave(proximo)] {
+					visitados[chave(proximo)] = true
+					nos = append(nos, proximo)
+				}
+			}
+		}
+		if atual.posicao < len(AP.Cadeia) {
+			for _, transicao := range AP.Transicoes[atual.estado][AP.Cadeia[atual.posicao]] {
+				if pilha, ok := aplicarPilha(atual.pilha, transicao); ok {
+					proximo := no{transicao.Destino, atual.posicao + 1, pilha, i}
+					if !visitados[chave(proximo)] {
+						visitados[chave(proximo)] = true
+						nos = append(nos, proximo)
+					}
+				}
+			}
+		}
+	}
+	return ResultadoPilha{}
+}
+
+func (AP *AutomatoPilha) aceitaConfiguracao(estado string, pilha string) bool {
+	if AP.Aceitacao == AceitacaoPilhaVazia {
+		return pilha == ""
+	}
+	return slices.Contains(AP.EstadosFinais, estado)
+}
+
+// aplicarPilha retorna a pilha após a transição, ou false se o topo não corresponde a Desempilha.
+func aplicarPilha(pilha string, transicao TransicaoPilha) (string, bool) {
+	if !strings.HasPrefix(pilha, transicao.Desempilha) {
+		return "", false
+	}
+	return transicao.Empilha + pilha[len(transicao.Desempilha):], true
+}
+
+func exibicaoTracePilha(resultado ResultadoPilha) {
+	switch {
+	case resultado.Aceita:
+		fmt.Println("Cadeia aceita:")
+		for i, configuracao := range resultado.Trace {
+			if i > 0 {
+				fmt.Print(" ⊢ ")
+			}
+			fmt.Print(configuracao)
+		}
+		fmt.Println()
+	case resultado.LimiteAtingido:
+		fmt.Println("Limite de passos atingido sem aceitar a cadeia")
+	default:
+		fmt.Println("Cadeia não aceita")
+	}
+}
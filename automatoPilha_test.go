@@ -0,0 +1,130 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestFuncionamentoPilha(t *testing.T) {
+	// a^n b^n (n >= 0), aceitação por estado final
+	anbn := AutomatoPilha{}
+	anbn.adicionarEstado("q0")
+	anbn.adicionarEstado("q1")
+	anbn.adicionarEstado("q2")
+	anbn.adicionarTransicao("q0", 'a', "", "A", "q0")
+	anbn.adicionarTransicaoEpsilon("q0", "", "", "q1")
+	anbn.adicionarTransicao("q1", 'b', "A", "", "q1")
+	anbn.adicionarTransicaoEpsilon("q1", "Z", "Z", "q2")
+	anbn.adicionarEstadoInicial("q0", "Z")
+	anbn.adicionarEstadoFinal("q2")
+
+	// Palíndromos pares w·wᴿ sobre {a, b}, aceitação por pilha vazia
+	palindromos := AutomatoPilha{Aceitacao: AceitacaoPilhaVazia}
+	palindromos.adicionarEstado("empilha")
+	palindromos.adicionarEstado("compara")
+	for _, simbolo := range []rune{'a', 'b'} {
+		palindromos.adicionarTransicao("empilha", simbolo, "", string(simbolo), "empilha")
+		palindromos.adicionarTransicao("compara", simbolo, string(simbolo), "", "compara")
+	}
+	palindromos.adicionarTransicaoEpsilon("empilha", "", "", "compara")
+	palindromos.adicionarEstadoInicial("empilha", "")
+
+	tests := []struct {
+		name     string
+		ap       *AutomatoPilha
+		cadeia   string
+		expected bool
+	}{
+		{"anbn_empty", &anbn, "", true},
+		{"anbn_ab", &anbn, "ab", true},
+		{"anbn_aaabbb", &anbn, "aaabbb", true},
+		{"anbn_aab", &anbn, "aab", false},
+		{"anbn_abb", &anbn, "abb", false},
+		{"anbn_ba", &anbn, "ba", false},
+		{"pal_empty", &palindromos, "", true},
+		{"pal_abba", &palindromos, "abba", true},
+		{"pal_aa", &palindromos, "aa", true},
+		{"pal_aba", &palindromos, "aba", false},
+		{"pal_ab", &palindromos, "ab", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.ap.adicionarCadeia(tt.cadeia)
+			resultado := tt.ap.funcionamento()
+			if resultado.Aceita != tt.expected {
+				t.Errorf("cadeia \"%s\": got %v, want %v", tt.cadeia, resultado.Aceita, tt.expected)
+			}
+			if resultado.Aceita && len(resultado.Trace) == 0 {
+				t.Errorf("cadeia \"%s\" aceita sem trace", tt.cadeia)
+			}
+		})
+	}
+}
+
+func TestFuncionamentoPilhaTrace(t *testing.T) {
+	ap := AutomatoPilha{}
+	ap.adicionarEstado("q0")
+	ap.adicionarEstado("q1")
+	ap.adicionarTransicao("q0", 'a', "Z", "AZ", "q0")
+	ap.adicionarTransicao("q0", 'b', "A", "", "q1")
+	ap.adicionarEstadoInicial("q0", "Z")
+	ap.adicionarEstadoFinal("q1")
+
+	ap.adicionarCadeia("ab")
+	resultado := ap.funcionamento()
+	expected := []string{"(q0, ab, Z)", "(q0, b, AZ)", "(q1, ε, Z)"}
+	if len(resultado.Trace) != len(expected) {
+		t.Fatalf("Trace = %v, want %v", resultado.Trace, expected)
+	}
+	for i, configuracao := range resultado.Trace {
+		if configuracao.String() != expected[i] {
+			t.Errorf("Trace[%d] = %s, want %s", i, configuracao, expected[i])
+		}
+	}
+}
+
+func TestFuncionamentoPilhaSimboloEpsilon(t *testing.T) {
+	// εⁿ·αⁿ com ε como letra do alfabeto; a transição épsilon fica separada
+	ap := AutomatoPilha{}
+	ap.adicionarEstado("q0")
+	ap.adicionarEstado("q1")
+	ap.adicionarEstado("q2")
+	ap.adicionarTransicao("q0", 'ε', "", "E", "q0")
+	ap.adicionarTransicaoEpsilon("q0", "", "", "q1")
+	ap.adicionarTransicao("q1", 'α', "E", "", "q1")
+	ap.adicionarTransicaoEpsilon("q1", "Z", "Z", "q2")
+	ap.adicionarEstadoInicial("q0", "Z")
+	ap.adicionarEstadoFinal("q2")
+
+	for _, tt := range []struct {
+		cadeia   string
+		expected bool
+	}{{"", true}, {"εα", true}, {"εεαα", true}, {"ε", false}, {"α", false}} {
+		ap.adicionarCadeia(tt.cadeia)
+		if got := ap.funcionamento().Aceita; got != tt.expected {
+			t.Errorf("cadeia %q: got %v, want %v", tt.cadeia, got, tt.expected)
+		}
+	}
+
+	ap.adicionarCadeia("εα")
+	if trace := ap.funcionamento().Trace; len(trace) == 0 || trace[0].String() != "(q0, \\εα, Z)" {
+		t.Errorf("Trace = %v, want início (q0, \\εα, Z)", trace)
+	}
+}
+
+func TestFuncionamentoPilhaLimitePassos(t *testing.T) {
+	// Laço épsilon que empilha para sempre; nenhuma configuração é de aceitação
+	ap := AutomatoPilha{LimitePassos: 50}
+	ap.adicionarEstado("q0")
+	ap.adicionarEstado("q1")
+	ap.adicionarTransicaoEpsilon("q0", "", "X", "q0")
+	ap.adicionarTransicao("q1", 'a', "", "", "q1")
+	ap.adicionarEstadoInicial("q0", "")
+	ap.adicionarEstadoFinal("q1")
+
+	ap.adicionarCadeia("a")
+	resultado := ap.funcionamento()
+	if resultado.Aceita || !resultado.LimiteAtingido {
+		t.Errorf("funcionamento() = %+v, want limite atingido sem aceitação", resultado)
+	}
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"errors"
+	"maps"
+	"math"
+	"slices"
+)
+
+// Semianel define as operações usadas para combinar pesos: Produto ao longo de um caminho e Soma
+// entre caminhos alternativos.
+type Semianel[P any] interface {
+	Zero() P // neutro da Soma e absorvente do Produto (caminho inexistente)
+	Um() P   // neutro do Produto (caminho vazio)
+	Soma(a, b P) P
+	Produto(a, b P) P
+	Igual(a, b P) bool
+}
+
+// SemianelBooleano (∨, ∧) reproduz a aceitação de funcionamento.
+type SemianelBooleano struct{}
+
+func (SemianelBooleano) Zero() bool             { return false }
+func (SemianelBooleano) Um() bool               { return true }
+func (SemianelBooleano) Soma(a, b bool) bool    { return a || b }
+func (SemianelBooleano) Produto(a, b bool) bool { return a && b }
+func (SemianelBooleano) Igual(a, b bool) bool   { return a == b }
+
+// SemianelTropical (min, +) dá o custo do caminho mais barato.
+type SemianelTropical struct{}
+
+func (SemianelTropical) Zero() float64                { return math.Inf(1) }
+func (SemianelTropical) Um() float64                  { return 0 }
+func (SemianelTropical) Soma(a, b float64) float64    { return math.Min(a, b) }
+func (SemianelTropical) Produto(a, b float64) float64 { return a + b }
+func (SemianelTropical) Igual(a, b float64) bool      { return a == b }
+
+// SemianelProbabilidade (+, ×) dá a probabilidade total dos caminhos.
+type SemianelProbabilidade struct{}
+
+func (SemianelProbabilidade) Zero() float64                { return 0 }
+func (SemianelProbabilidade) Um() float64                  { return 1 }
+func (SemianelProbabilidade) Soma(a, b float64) float64    { return a + b }
+func (SemianelProbabilidade) Produto(a, b float64) float64 { return a * b }
+func (SemianelProbabilidade) Igual(a, b float64) bool      { return math.Abs(a-b) < 1e-12 }
+
+// SemianelContagem (+, ×) sobre inteiros conta caminhos.
+type SemianelContagem struct{}
+
+func (SemianelContagem) Zero() uint64               { return 0 }
+func (SemianelContagem) Um() uint64                 { return 1 }
+func (SemianelContagem) Soma(a, b uint64) uint64    { return a + b }
+func (SemianelContagem) Produto(a, b uint64) uint64 { return a * b }
+func (SemianelContagem) Igual(a, b uint64) bool     { return a == b }
+
+type TransicaoPonderada[P any] struct {
+	Destino string
+	Peso    P
+}
+
+// AutomatoPonderado segue o modelo de AutomatoFinito, mas cada transição carrega um peso e cada
+// estado final um peso de saída. Transições épsilon não são suportadas.
+type AutomatoPonderado[P any] struct {
+	Semianel      Semianel[P]
+	Estados       []string
+	Alfabeto      []rune
+	Transicoes    map[string]map[rune][]TransicaoPonderada[P] // estadoOrigem: [símbolo: [transições]]
+	EstadoInicial string
+	PesosFinais   map[string]P // estado final: peso de saída
+}
+
+func (AP *AutomatoPonderado[P]) adicionarEstado(estado string) {
+	AP.Estados = append(AP.Estados, estado)
+}
+
+func (AP *AutomatoPonderado[P]) adicionarTransicao(estadoOrigem string, simbolo rune, estadoDestino string, peso P) {
+	if AP.Transicoes == nil {
+		AP.Transicoes = make(map[string]map[rune][]TransicaoPonderada[P])
+	}
+	if AP.Transicoes[estadoOrigem] == nil {
+		AP.Transicoes[estadoOrigem] = make(map[rune][]TransicaoPonderada[P])
+	}
+	AP.Transicoes[estadoOrigem][simbolo] = append(AP.Transicoes[estadoOrigem][simbolo], TransicaoPonderada[P]{estadoDestino, peso})
+}
+
+func (AP *AutomatoPonderado[P]) adicionarEstadoInicial(estadoInicial string) {
+	AP.EstadoInicial = estadoInicial
+}
+
+func (AP *AutomatoPonderado[P]) adicionarEstadoFinal(estadoFinal string, peso P) {
+	if AP.PesosFinais == nil {
+		AP.PesosFinais = make(map[string]P)
+	}
+	AP.PesosFinais[estadoFinal] = peso
+}
+
+// peso calcula a Soma, sobre todos os caminhos que leem a cadeia, do Produto dos pesos do caminho
+// e do peso de saída do estado final.
+func (AP *AutomatoPonderado[P]) peso(cadeia string) P {
+	S := AP.Semianel
+	atuais := map[string]P{AP.EstadoInicial: S.Um()}
+	for _, simbolo := range cadeia {
+		proximos := make(map[string]P)
+		for estado, acumulado := range atuais {
+			for _, transicao := range AP.Transicoes[estado][simbolo] {
+				anterior, ok := proximos[transicao.Destino]
+				if !ok {
+					anterior = S.Zero()
+				}
+				proximos[transicao.Destino] = S.Soma(anterior, S.Produto(acumulado, transicao.Peso))
+			}
+		}
+		atuais = proximos
+	}
+
+	total := S.Zero()
+	for estado, acumulado := range atuais {
+		if pesoFinal, ok := AP.PesosFinais[estado]; ok {
+			total = S.Soma(total, S.Produto(acumulado, pesoFinal))
+		}
+	}
+	return total
+}
+
+// arestaPonderada identifica a transição pela qual um estado foi alcançado no melhor caminho.
+type arestaPonderada struct {
+	origem  string
+	simbolo rune
+}
+
+var errDistanciaNaoConverge = errors.New("a distância não convergiu; o semianel não é k-fechado para os ciclos deste autômato")
+
+var errCaminhoIncompleto = errors.New("o melhor caminho não pôde ser reconstruído a partir dos predecessores")
+
+// limiteRelaxacoes é |Q|·|δ|, o máximo de relaxações da fila em semianéis 0-fechados (como o tropical
+// com pesos não negativos), como no algoritmo de Bellman-Ford. O mínimo limitePassosPadrao deixa
+// convergir, em autômatos pequenos, os semianéis que só convergem aproximadamente (probabilidade).
+func (AP *AutomatoPonderado[P]) limiteRelaxacoes() int {
+	estados := map[string]bool{AP.EstadoInicial: true}
+	for _, estado := range AP.Estados {
+		estados[estado] = true
+	}
+	transicoes := 0
+	for origem, m := range AP.Transicoes {
+		estados[origem] = true
+		for _, lista := range m {
+			transicoes += len(lista)
+			for _, transicao := range lista {
+				estados[transicao.Destino] = true
+			}
+		}
+	}
+	return max(limitePassosPadrao, len(estados)*transicoes)
+}
+
+// menorDistancia calcula, para cada estado, a Soma dos pesos de todos os caminhos a partir do
+// estado inicial (algoritmo genérico de Mohri). Termina para semianéis k-fechados, como o booleano e
+// o tropical com pesos não negativos; nos demais, ciclos podem impedir a convergência, o que é
+// detectado pelo limite de relaxações (limiteRelaxacoes). Também retorna, para cada estado, a última
+// transição que melhorou sua distância, usada por melhorCaminho.
+func (AP *AutomatoPonderado[P]) menorDistancia() (map[string]P, map[string]arestaPonderada, error) {
+	S := AP.Semianel
+	distancia := make(map[string]P)
+	residuo := make(map[string]P)
+	predecessor := make(map[string]arestaPonderada)
+	valor := func(m map[string]P, estado string) P {
+		if v, ok := m[estado]; ok {
+			return v
+		}
+		return S.Zero()
+	}
+
+	distancia[AP.EstadoInicial] = S.Um()
+	residuo[AP.EstadoInicial] = S.Um()
+	fila := []string{AP.EstadoInicial}
+	naFila := map[string]bool{AP.EstadoInicial: true}
+	limite := AP.limiteRelaxacoes()
+	for relaxacoes := 0; len(fila) > 0; {
+		estado := fila[0]
+		fila = fila[1:]
+		naFila[estado] = false
+		r := valor(residuo, estado)
+		residuo[estado] = S.Zero()
+
+		for _, simbolo := range slices.Sorted(maps.Keys(AP.Transicoes[estado])) {
+			for _, transicao := range AP.Transicoes[estado][simbolo] {
+				candidato := S.Produto(r, transicao.Peso)
+				atual := valor(distancia, transicao.Destino)
+				novo := S.Soma(atual, candidato)
+				if S.Igual(novo, atual) {
+					continue
+				}
+				if relaxacoes++; relaxacoes > limite {
+					return nil, nil, errDistanciaNaoConverge
+				}
+				distancia[transicao.Destino] = novo
+				residuo[transicao.Destino] = S.Soma(valor(residuo, transicao.Destino), candidato)
+				if S.Igual(novo, candidato) {
+					predecessor[transicao.Destino] = arestaPonderada{estado, simbolo}
+				}
+				if !naFila[transicao.Destino] {
+					naFila[transicao.Destino] = true
+					fila = append(fila, transicao.Destino)
+				}
+			}
+		}
+	}
+	return distancia, predecessor, nil
+}
+
+// melhorCaminho retorna a cadeia lida pelo melhor caminho até um estado final e o seu peso total.
+// Só faz sentido em semianéis seletivos, em que a Soma escolhe um dos operandos (booleano,
+// tropical). Retorna false quando nenhum estado final é alcançável e um erro quando os predecessores
+// não levam de volta ao estado inicial.
+func (AP *AutomatoPonderado[P]) melhorCaminho() (string, P, bool, error) {
+	S := AP.Semianel
+	distancia, predecessor, err := AP.menorDistancia()
+	if err != nil {
+		return "", S.Zero(), false, err
+	}
+
+	melhor, total, encontrado := "", S.Zero(), false
+	for _, estado := range chavesOrdenadas(AP.PesosFinais) {
+		d, ok := distancia[estado]
+		if !ok {
+			continue
+		}
+		candidato := S.Produto(d, AP.PesosFinais[estado])
+		if !encontrado || (S.Igual(S.Soma(total, candidato), candidato) && !S.Igual(total, candidato)) {
+			melhor, total, encontrado = estado, candidato, true
+		}
+	}
+	if !encontrado {
+		return "", S.Zero(), false, nil
+	}
+
+	var cadeia []rune
+	visitados := make(map[string]bool)
+	for estado := melhor; estado != AP.EstadoInicial; {
+		aresta, ok := predecessor[estado]
+		if !ok || visitados[estado] {
+			return "", S.Zero(), false, errCaminhoIncompleto
+		}
+		visitados[estado] = true
+		cadeia = append(cadeia, aresta.simbolo)
+		estado = aresta.origem
+	}
+	slices.Reverse(cadeia)
+	return string(cadeia), total, true, nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestPesoSemianeis(t *testing.T) {
+	// Termina em "ab": duas formas de ler 'a' em q0, para contar caminhos
+	contagem := AutomatoPonderado[uint64]{Semianel: SemianelContagem{}}
+	contagem.adicionarTransicao("q0", 'a', "q0", 1)
+	contagem.adicionarTransicao("q0", 'b', "q0", 1)
+	contagem.adicionarTransicao("q0", 'a', "q1", 1)
+	contagem.adicionarTransicao("q1", 'b', "q2", 1)
+	contagem.adicionarTransicao("q0", 'a', "q3", 1)
+	contagem.adicionarTransicao("q3", 'b', "q2", 1)
+	contagem.adicionarEstadoInicial("q0")
+	contagem.adicionarEstadoFinal("q2", 1)
+
+	if got := contagem.peso("aab"); got != 2 {
+		t.Errorf("contagem.peso(\"aab\") = %d, want 2", got)
+	}
+	if got := contagem.peso("ba"); got != 0 {
+		t.Errorf("contagem.peso(\"ba\") = %d, want 0", got)
+	}
+
+	// Cada 'a' custa 1 por um caminho e 3 pelo outro; o final custa 0.5
+	tropical := AutomatoPonderado[float64]{Semianel: SemianelTropical{}}
+	tropical.adicionarTransicao("q0", 'a', "q1", 1)
+	tropical.adicionarTransicao("q0", 'a', "q2", 3)
+	tropical.adicionarTransicao("q1", 'a', "q1", 1)
+	tropical.adicionarTransicao("q2", 'a', "q1", 0)
+	tropical.adicionarEstadoInicial("q0")
+	tropical.adicionarEstadoFinal("q1", 0.5)
+
+	if got := tropical.peso("aa"); got != 2.5 {
+		t.Errorf("tropical.peso(\"aa\") = %v, want 2.5", got)
+	}
+	if got := tropical.peso("b"); !math.IsInf(got, 1) {
+		t.Errorf("tropical.peso(\"b\") = %v, want +Inf", got)
+	}
+
+	// Moeda: 'c' (cara) com 0.5 e 'k' (coroa) com 0.5, parada com 1
+	probabilidade := AutomatoPonderado[float64]{Semianel: SemianelProbabilidade{}}
+	probabilidade.adicionarTransicao("q0", 'c', "q0", 0.5)
+	probabilidade.adicionarTransicao("q0", 'k', "q0", 0.5)
+	probabilidade.adicionarEstadoInicial("q0")
+	probabilidade.adicionarEstadoFinal("q0", 1)
+
+	if got := probabilidade.peso("ckc"); math.Abs(got-0.125) > 1e-12 {
+		t.Errorf("probabilidade.peso(\"ckc\") = %v, want 0.125", got)
+	}
+
+	booleano := AutomatoPonderado[bool]{Semianel: SemianelBooleano{}}
+	booleano.adicionarTransicao("q0", 'a', "q1", true)
+	booleano.adicionarEstadoInicial("q0")
+	booleano.adicionarEstadoFinal("q1", true)
+
+	if !booleano.peso("a") || booleano.peso("aa") {
+		t.Errorf("booleano.peso deveria aceitar apenas \"a\"")
+	}
+}
+
+func TestMelhorCaminhoTropical(t *testing.T) {
+	// Grafo com ciclo; o caminho mais barato até q3 é q0 -b-> q2 -a-> q1 -b-> q3 (custo 4)
+	AP := AutomatoPonderado[float64]{Semianel: SemianelTropical{}}
+	for _, estado := range []string{"q0", "q1", "q2", "q3"} {
+		AP.adicionarEstado(estado)
+	}
+	AP.adicionarTransicao("q0", 'a', "q1", 5)
+	AP.adicionarTransicao("q0", 'b', "q2", 1)
+	AP.adicionarTransicao("q2", 'a', "q1", 1)
+	AP.adicionarTransicao("q1", 'a', "q0", 1)
+	AP.adicionarTransicao("q1", 'b', "q3", 2)
+	AP.adicionarEstadoInicial("q0")
+	AP.adicionarEstadoFinal("q3", 0)
+
+	distancia, _, err := AP.menorDistancia()
+	if err != nil {
+		t.Fatalf("menorDistancia() erro inesperado: %v", err)
+	}
+	expected := map[string]float64{"q0": 0, "q1": 2, "q2": 1, "q3": 4}
+	for estado, d := range expected {
+		if distancia[estado] != d {
+			t.Errorf("distancia[%s] = %v, want %v", estado, distancia[estado], d)
+		}
+	}
+
+	cadeia, total, ok, err := AP.melhorCaminho()
+	if err != nil || !ok {
+		t.Fatalf("melhorCaminho() = (%v, %v), want (true, nil)", ok, err)
+	}
+	if cadeia != "bab" || total != 4 {
+		t.Errorf("melhorCaminho() = (%q, %v), want (\"bab\", 4)", cadeia, total)
+	}
+	if got := AP.peso(cadeia); got != total {
+		t.Errorf("peso(%q) = %v, want %v", cadeia, got, total)
+	}
+}
+
+func TestMelhorCaminhoCadeiaLonga(t *testing.T) {
+	// Uma cadeia acíclica maior que limitePassosPadrao converge em uma relaxação por transição.
+	// Os estados não são declarados em Estados, e o caminho deve ser reconstruído inteiro mesmo assim
+	const n = limitePassosPadrao + 2
+	AP := AutomatoPonderado[float64]{Semianel: SemianelTropical{}}
+	for i := range n - 1 {
+		AP.adicionarTransicao(fmt.Sprintf("q%d", i), 'a', fmt.Sprintf("q%d", i+1), 1)
+	}
+	AP.adicionarEstadoInicial("q0")
+	AP.adicionarEstadoFinal(fmt.Sprintf("q%d", n-1), 0)
+
+	cadeia, total, ok, err := AP.melhorCaminho()
+	if err != nil || !ok {
+		t.Fatalf("melhorCaminho() = (%v, %v), want (true, nil)", ok, err)
+	}
+	if len(cadeia) != n-1 || total != n-1 {
+		t.Errorf("melhorCaminho() = (cadeia de %d símbolos, %v), want (%d, %d)", len(cadeia), total, n-1, n-1)
+	}
+}
+
+func TestMenorDistanciaNaoConverge(t *testing.T) {
+	// Contar caminhos num ciclo não termina
+	AP := AutomatoPonderado[uint64]{Semianel: SemianelContagem{}}
+	AP.adicionarTransicao("q0", 'a', "q0", 1)
+	AP.adicionarEstadoInicial("q0")
+	AP.adicionarEstadoFinal("q0", 1)
+
+	if _, _, err := AP.menorDistancia(); err == nil {
+		t.Errorf("menorDistancia() deveria detectar a falta de convergência")
+	}
+}
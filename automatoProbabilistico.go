@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"math/rand"
+	"slices"
+)
+
+// AutomatoProbabilistico acrescenta a um AutomatoFinito uma distribuição de probabilidade por
+// estado: as probabilidades das transições que saem do estado mais a de parar nele somam 1.
+// EstadosFinais não é usado; a parada faz o papel da aceitação. Transições épsilon não são suportadas;
+// 'ε' é um símbolo como os outros.
+type AutomatoProbabilistico struct {
+	AutomatoFinito
+	Probabilidades      map[string]map[rune]map[string]float64 // estadoOrigem: [símbolo: [estadoDestino: probabilidade]]
+	ProbabilidadeParada map[string]float64
+}
+
+// toleranciaProbabilidade é a diferença aceita entre a soma das probabilidades de um estado e 1.
+const toleranciaProbabilidade = 1e-9
+
+func (AP *AutomatoProbabilistico) adicionarTransicaoProbabilidade(estadoOrigem string, simbolo rune, estadoDestino string, probabilidade float64) {
+	AP.adicionarTransicao(estadoOrigem, simbolo, estadoDestino)
+	if AP.Probabilidades == nil {
+		AP.Probabilidades = make(map[string]map[rune]map[string]float64)
+	}
+	if AP.Probabilidades[estadoOrigem] == nil {
+		AP.Probabilidades[estadoOrigem] = make(map[rune]map[string]float64)
+	}
+	if AP.Probabilidades[estadoOrigem][simbolo] == nil {
+		AP.Probabilidades[estadoOrigem][simbolo] = make(map[string]float64)
+	}
+	AP.Probabilidades[estadoOrigem][simbolo][estadoDestino] += probabilidade
+}
+
+func (AP *AutomatoProbabilistico) adicionarParada(estado string, probabilidade float64) {
+	if AP.ProbabilidadeParada == nil {
+		AP.ProbabilidadeParada = make(map[string]float64)
+	}
+	AP.ProbabilidadeParada[estado] = probabilidade
+}
+
+// validarDistribuicoes verifica se, em cada estado, as probabilidades são não negativas e somam 1.
+func (AP *AutomatoProbabilistico) validarDistribuicoes() error {
+	for _, origem := range chavesOrdenadas(AP.TransicoesEpsilon) {
+		if len(AP.TransicoesEpsilon[origem]) > 0 {
+			return fmt.Errorf("estado %s: transições épsilon não são suportadas", origem)
+		}
+	}
+	for _, estado := range AP.Estados {
+		soma := AP.ProbabilidadeParada[estado]
+		if soma < 0 {
+			return fmt.Errorf("estado %s: probabilidade de parada negativa", estado)
+		}
+		for simbolo, destinos := range AP.Probabilidades[estado] {
+			for _, probabilidade := range destinos {
+				if probabilidade < 0 {
+					return fmt.Errorf("estado %s: probabilidade negativa na transição com %q", estado, simbolo)
+				}
+				soma += probabilidade
+			}
+		}
+		if math.Abs(soma-1) > toleranciaProbabilidade {
+			return fmt.Errorf("estado %s: as probabilidades somam %g, não 1", estado, soma)
+		}
+	}
+	return nil
+}
+
+// probabilidade retorna a probabilidade de o autômato gerar exatamente a cadeia e parar, somando
+// sobre todos os caminhos que a leem.
+func (AP *AutomatoProbabilistico) probabilidade(cadeia string) float64 {
+	atuais := map[string]float64{AP.EstadoInicial: 1}
+	for _, simbolo := range cadeia {
+		proximos := make(map[string]float64)
+		// A ordem das somas é fixa para que o arredondamento seja o mesmo a cada execução
+		for _, estado := range chavesOrdenadas(atuais) {
+			destinos := AP.Probabilidades[estado][simbolo]
+			for _, destino := range chavesOrdenadas(destinos) {
+				proximos[destino] += atuais[estado] * destinos[destino]
+			}
+		}
+		atuais = proximos
+	}
+
+	total := 0.0
+	for _, estado := range chavesOrdenadas(atuais) {
+		total += atuais[estado] * AP.ProbabilidadeParada[estado]
+	}
+	return total
+}
+
+var errAmostraLonga = errors.New("a amostra passou do limite de tamanho; verifique as probabilidades de parada")
+
+// amostrar gera uma cadeia seguindo as distribuições a partir do estado inicial até parar. As
+// escolhas percorrem símbolos e destinos em ordem, de forma que o mesmo gerador produz a mesma cadeia.
+func (AP *AutomatoProbabilistico) amostrar(gerador *rand.Rand) (string, error) {
+	var cadeia []rune
+	estado := AP.EstadoInicial
+	for len(cadeia) < limitePassosPadrao {
+		sorteio := gerador.Float64()
+		acumulada := AP.ProbabilidadeParada[estado]
+		if sorteio < acumulada {
+			return string(cadeia), nil
+		}
+
+		escolhido := false
+		for _, simbolo := range slices.Sorted(maps.Keys(AP.Probabilidades[estado])) {
+			destinos := AP.Probabilidades[estado][simbolo]
+			for _, destino := range chavesOrdenadas(destinos) {
+				acumulada += destinos[destino]
+				if sorteio < acumulada {
+					cadeia = append(cadeia, simbolo)
+					estado, escolhido = destino, true
+					break
+				}
+			}
+			if escolhido {
+				break
+			}
+		}
+		if !escolhido {
+			// Só acontece por arredondamento quando a soma do estado fica ligeiramente abaixo de 1
+			return string(cadeia), nil
+		}
+	}
+	return "", errAmostraLonga
+}
+
+// amostrarCadeias gera n cadeias com um gerador criado a partir da semente, para que a mesma
+// semente reproduza o mesmo conjunto de testes.
+func (AP *AutomatoProbabilistico) amostrarCadeias(n int, semente int64) ([]string, error) {
+	if err := AP.validarDistribuicoes(); err != nil {
+		return nil, err
+	}
+	gerador := rand.New(rand.NewSource(semente))
+	cadeias := make([]string, 0, n)
+	for range n {
+		cadeia, err := AP.amostrar(gerador)
+		if err != nil {
+			return nil, err
+		}
+		cadeias = append(cadeias, cadeia)
+	}
+	return cadeias, nil
+}
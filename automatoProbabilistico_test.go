@@ -0,0 +1,110 @@
+package main
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// novoAutomatoProbabilisticoAB gera a^n b: em q0 lê 'a' (0.5) ou 'b' (0.5); em q1 para sempre.
+func novoAutomatoProbabilisticoAB() AutomatoProbabilistico {
+	AP := AutomatoProbabilistico{}
+	AP.adicionarEstado("q0")
+	AP.adicionarEstado("q1")
+	AP.adicionarEstadoInicial("q0")
+	AP.adicionarTransicaoProbabilidade("q0", 'a', "q0", 0.5)
+	AP.adicionarTransicaoProbabilidade("q0", 'b', "q1", 0.5)
+	AP.adicionarParada("q1", 1)
+	return AP
+}
+
+func TestProbabilidade(t *testing.T) {
+	AP := novoAutomatoProbabilisticoAB()
+	if err := AP.validarDistribuicoes(); err != nil {
+		t.Fatalf("validarDistribuicoes() erro inesperado: %v", err)
+	}
+
+	tests := []struct {
+		cadeia   string
+		expected float64
+	}{
+		{"b", 0.5},
+		{"ab", 0.25},
+		{"aaab", 0.0625},
+		{"", 0},
+		{"ba", 0},
+	}
+	for _, tt := range tests {
+		if got := AP.probabilidade(tt.cadeia); math.Abs(got-tt.expected) > 1e-12 {
+			t.Errorf("probabilidade(%q) = %v, want %v", tt.cadeia, got, tt.expected)
+		}
+	}
+
+	// Caminhos alternativos somam: "a" pode ir a p1 ou p2, ambos param com probabilidades diferentes
+	ambiguo := AutomatoProbabilistico{}
+	ambiguo.adicionarEstado("p0")
+	ambiguo.adicionarEstado("p1")
+	ambiguo.adicionarEstado("p2")
+	ambiguo.adicionarEstadoInicial("p0")
+	ambiguo.adicionarTransicaoProbabilidade("p0", 'a', "p1", 0.3)
+	ambiguo.adicionarTransicaoProbabilidade("p0", 'a', "p2", 0.7)
+	ambiguo.adicionarParada("p1", 1)
+	ambiguo.adicionarParada("p2", 0.5)
+	ambiguo.adicionarTransicaoProbabilidade("p2", 'a', "p2", 0.5)
+	if got := ambiguo.probabilidade("a"); math.Abs(got-0.65) > 1e-12 {
+		t.Errorf("probabilidade(\"a\") = %v, want 0.65", got)
+	}
+}
+
+func TestValidarDistribuicoes(t *testing.T) {
+	AP := novoAutomatoProbabilisticoAB()
+	AP.adicionarParada("q0", 0.1)
+	if err := AP.validarDistribuicoes(); err == nil || !strings.Contains(err.Error(), "q0") {
+		t.Errorf("validarDistribuicoes() = %v, want erro sobre q0", err)
+	}
+
+	// 'ε' é um símbolo comum; já uma transição épsilon não pode ter probabilidade
+	grego := AutomatoProbabilistico{}
+	grego.adicionarEstado("p0")
+	grego.adicionarEstadoInicial("p0")
+	grego.adicionarTransicaoProbabilidade("p0", 'ε', "p0", 0.5)
+	grego.adicionarParada("p0", 0.5)
+	if err := grego.validarDistribuicoes(); err != nil {
+		t.Errorf("validarDistribuicoes() com o símbolo ε: erro inesperado %v", err)
+	}
+	if got := grego.probabilidade("ε"); math.Abs(got-0.25) > 1e-12 {
+		t.Errorf("probabilidade(\"ε\") = %v, want 0.25", got)
+	}
+	grego.adicionarTransicaoEpsilon("p0", "p0")
+	if err := grego.validarDistribuicoes(); err == nil || !strings.Contains(err.Error(), "épsilon") {
+		t.Errorf("validarDistribuicoes() com transição épsilon = %v, want erro", err)
+	}
+}
+
+func TestAmostrarCadeias(t *testing.T) {
+	AP := novoAutomatoProbabilisticoAB()
+
+	primeira, err := AP.amostrarCadeias(200, 42)
+	if err != nil {
+		t.Fatalf("amostrarCadeias() erro inesperado: %v", err)
+	}
+	segunda, _ := AP.amostrarCadeias(200, 42)
+	if !slices.Equal(primeira, segunda) {
+		t.Errorf("a mesma semente deveria gerar as mesmas cadeias")
+	}
+
+	apenasB := 0
+	for _, cadeia := range primeira {
+		if AP.probabilidade(cadeia) == 0 {
+			t.Fatalf("amostra %q tem probabilidade zero", cadeia)
+		}
+		if cadeia == "b" {
+			apenasB++
+		}
+	}
+	// P("b") = 0.5; com 200 amostras a frequência fica longe dos extremos
+	if apenasB < 70 || apenasB > 130 {
+		t.Errorf("frequência de \"b\" = %d/200, esperado perto de 100", apenasB)
+	}
+}
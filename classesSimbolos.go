@@ -0,0 +1,213 @@
+package main
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Intervalo é o intervalo fechado de runas [De, Ate].
+type Intervalo struct {
+	De, Ate rune
+}
+
+// ClasseSimbolos é um conjunto de runas guardado como intervalos ordenados, disjuntos e não
+// adjacentes, de modo que classes como \p{L} não precisam ser enumeradas símbolo a símbolo.
+type ClasseSimbolos []Intervalo
+
+// novaClasse normaliza os intervalos dados (em qualquer ordem, podendo se sobrepor).
+func novaClasse(intervalos ...Intervalo) ClasseSimbolos {
+	ordenados := slices.Clone(intervalos)
+	slices.SortFunc(ordenados, func(a, b Intervalo) int { return int(a.De - b.De) })
+	var classe ClasseSimbolos
+	for _, intervalo := range ordenados {
+		if intervalo.De > intervalo.Ate {
+			continue
+		}
+		if n := len(classe); n > 0 && intervalo.De <= classe[n-1].Ate+1 {
+			classe[n-1].Ate = max(classe[n-1].Ate, intervalo.Ate)
+			continue
+		}
+		classe = append(classe, intervalo)
+	}
+	return classe
+}
+
+// classeRuna é a classe de um único símbolo.
+func classeRuna(r rune) ClasseSimbolos {
+	return ClasseSimbolos{{r, r}}
+}
+
+// classeQualquer contém todas as runas; é o "qualquer outro símbolo" quando combinada com diferenca.
+func classeQualquer() ClasseSimbolos {
+	return ClasseSimbolos{{0, unicode.MaxRune}}
+}
+
+// lerClasse interpreta uma classe na sintaxe das expressões regulares de Go: um caractere ("a"),
+// colchetes com intervalos e negação ("[a-z_]", "[^0-9]"), classes Perl ("\d", "\w", "\s" e as
+// negadas "\D", "\W", "\S"), categorias e scripts Unicode ("\p{L}", "\p{Greek}", "\P{N}") e "."
+// para qualquer símbolo.
+func lerClasse(texto string) (ClasseSimbolos, error) {
+	expressao, err := syntax.Parse(texto, syntax.Perl|syntax.DotNL)
+	if err != nil {
+		return nil, fmt.Errorf("classe %q inválida: %w", texto, err)
+	}
+	expressao = expressao.Simplify()
+	switch {
+	case expressao.Op == syntax.OpLiteral && len(expressao.Rune) == 1 && expressao.Flags&syntax.FoldCase == 0:
+		return classeRuna(expressao.Rune[0]), nil
+	case expressao.Op == syntax.OpCharClass:
+		var intervalos []Intervalo
+		for i := 0; i+1 < len(expressao.Rune); i += 2 {
+			intervalos = append(intervalos, Intervalo{expressao.Rune[i], expressao.Rune[i+1]})
+		}
+		return novaClasse(intervalos...), nil
+	case expressao.Op == syntax.OpAnyChar:
+		return classeQualquer(), nil
+	}
+	return nil, fmt.Errorf("%q não é uma classe de um único símbolo", texto)
+}
+
+func (C ClasseSimbolos) vazia() bool {
+	return len(C) == 0
+}
+
+// contem faz busca binária pelo intervalo que poderia conter r.
+func (C ClasseSimbolos) contem(r rune) bool {
+	i, _ := slices.BinarySearchFunc(C, r, func(intervalo Intervalo, r rune) int {
+		switch {
+		case intervalo.Ate < r:
+			return -1
+		case intervalo.De > r:
+			return 1
+		}
+		return 0
+	})
+	return i < len(C) && C[i].De <= r && r <= C[i].Ate
+}
+
+func (C ClasseSimbolos) uniao(D ClasseSimbolos) ClasseSimbolos {
+	return novaClasse(append(slices.Clone(C), D...)...)
+}
+
+// complemento retorna as runas de 0 a unicode.MaxRune que não estão na classe.
+func (C ClasseSimbolos) complemento() ClasseSimbolos {
+	var resultado ClasseSimbolos
+	proximo := rune(0)
+	for _, intervalo := range C {
+		if intervalo.De > proximo {
+			resultado = append(resultado, Intervalo{proximo, intervalo.De - 1})
+		}
+		proximo = intervalo.Ate + 1
+	}
+	if proximo <= unicode.MaxRune {
+		resultado = append(resultado, Intervalo{proximo, unicode.MaxRune})
+	}
+	return resultado
+}
+
+func (C ClasseSimbolos) intersecao(D ClasseSimbolos) ClasseSimbolos {
+	return C.complemento().uniao(D.complemento()).complemento()
+}
+
+func (C ClasseSimbolos) diferenca(D ClasseSimbolos) ClasseSimbolos {
+	return C.intersecao(D.complemento())
+}
+
+// tamanho é o número de runas da classe.
+func (C ClasseSimbolos) tamanho() int {
+	total := 0
+	for _, intervalo := range C {
+		total += int(intervalo.Ate-intervalo.De) + 1
+	}
+	return total
+}
+
+// String escreve a classe na sintaxe de lerClasse. Quando o complemento é menor, usa [^...].
+func (C ClasseSimbolos) String() string {
+	switch {
+	case len(C) == 0:
+		return "[]"
+	case len(C) == 1 && C[0].De == C[0].Ate:
+		return escreverRunaClasse(C[0].De, false)
+	case len(C) == 1 && C[0].De == 0 && C[0].Ate == unicode.MaxRune:
+		return "."
+	}
+	prefixo, intervalos := "[", C
+	if complemento := C.complemento(); len(complemento) < len(C) {
+		prefixo, intervalos = "[^", complemento
+	}
+	var sb strings.Builder
+	sb.WriteString(prefixo)
+	for _, intervalo := range intervalos {
+		sb.WriteString(escreverRunaClasse(intervalo.De, true))
+		if intervalo.Ate > intervalo.De {
+			if intervalo.Ate > intervalo.De+1 {
+				sb.WriteString("-")
+			}
+			sb.WriteString(escreverRunaClasse(intervalo.Ate, true))
+		}
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
+
+// escreverRunaClasse escapa a runa para que lerClasse a leia de volta.
+func escreverRunaClasse(r rune, dentroColchetes bool) string {
+	especiais := `\.+*?()|[]{}^$`
+	if dentroColchetes {
+		especiais = `\[]^-`
+	}
+	switch {
+	case strings.ContainsRune(especiais, r):
+		return `\` + string(r)
+	case !unicode.IsGraphic(r) || unicode.IsSpace(r):
+		return fmt.Sprintf(`\x{%x}`, r)
+	}
+	return string(r)
+}
+
+// particionar divide as runas cobertas pelas classes em átomos disjuntos: dois símbolos ficam no
+// mesmo átomo quando pertencem exatamente às mesmas classes. Retorna os átomos, na ordem do menor
+// símbolo de cada um, e, para cada átomo, os índices das classes que o contêm. Runas fora de todas
+// as classes não formam átomo. O custo depende do número de intervalos, não do número de runas.
+func particionar(classes []ClasseSimbolos) ([]ClasseSimbolos, [][]int) {
+	var fronteiras []rune
+	for _, classe := range classes {
+		for _, intervalo := range classe {
+			fronteiras = append(fronteiras, intervalo.De, intervalo.Ate+1)
+		}
+	}
+	slices.Sort(fronteiras)
+	fronteiras = slices.Compact(fronteiras)
+
+	var atomos []ClasseSimbolos
+	var pertence [][]int
+	indice := make(map[string]int)
+	for i := 0; i+1 < len(fronteiras); i++ {
+		elementar := Intervalo{fronteiras[i], fronteiras[i+1] - 1}
+		var classesDoIntervalo []int
+		for j, classe := range classes {
+			if classe.contem(elementar.De) {
+				classesDoIntervalo = append(classesDoIntervalo, j)
+			}
+		}
+		if len(classesDoIntervalo) == 0 {
+			continue
+		}
+		chave := fmt.Sprint(classesDoIntervalo)
+		if k, ok := indice[chave]; ok {
+			atomos[k] = append(atomos[k], elementar)
+			continue
+		}
+		indice[chave] = len(atomos)
+		atomos = append(atomos, ClasseSimbolos{elementar})
+		pertence = append(pertence, classesDoIntervalo)
+	}
+	for k := range atomos {
+		atomos[k] = novaClasse(atomos[k]...)
+	}
+	return atomos, pertence
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"unicode"
+)
+
+func TestLerClasse(t *testing.T) {
+	tests := []struct {
+		texto  string
+		contem string
+		naoTem string
+	}{
+		{"a", "a", "bA"},
+		{"[a-z_]", "amz_", "A0-"},
+		{"[^a-z]", "A0-é", "am"},
+		{`\d`, "0459", "a٣"},
+		{`\w`, "aZ_9", "é-"},
+		{`\p{L}`, "aéжλ中", "1_ "},
+		{`\P{L}`, "1_ ", "aé"},
+		{`\p{Greek}`, "λΩ", "a"},
+		{`[\d\p{Lu}]`, "5É", "é"},
+		{".", "a\n\x00\U0010FFFF", ""},
+	}
+	for _, tt := range tests {
+		classe, err := lerClasse(tt.texto)
+		if err != nil {
+			t.Errorf("lerClasse(%q) erro inesperado: %v", tt.texto, err)
+			continue
+		}
+		for _, r := range tt.contem {
+			if !classe.contem(r) {
+				t.Errorf("lerClasse(%q) deveria conter %q", tt.texto, r)
+			}
+		}
+		for _, r := range tt.naoTem {
+			if classe.contem(r) {
+				t.Errorf("lerClasse(%q) não deveria conter %q", tt.texto, r)
+			}
+		}
+		if relida, err := lerClasse(classe.String()); err != nil || !reflect.DeepEqual(relida, classe) {
+			t.Errorf("lerClasse(%q.String() = %q) = %v, %v", tt.texto, classe.String(), relida, err)
+		}
+	}
+
+	for _, texto := range []string{"ab", "[a-", "a*", "(?i)a"} {
+		if _, err := lerClasse(texto); err == nil {
+			t.Errorf("lerClasse(%q) deveria falhar", texto)
+		}
+	}
+}
+
+func TestOperacoesClasse(t *testing.T) {
+	letras := novaClasse(Intervalo{'a', 'f'}, Intervalo{'d', 'k'}, Intervalo{'l', 'l'}, Intervalo{'x', 'z'})
+	if expected := (ClasseSimbolos{{'a', 'l'}, {'x', 'z'}}); !reflect.DeepEqual(letras, expected) {
+		t.Errorf("novaClasse() = %v, want %v", letras, expected)
+	}
+	if got := letras.String(); got != "[a-lx-z]" {
+		t.Errorf("String() = %q, want \"[a-lx-z]\"", got)
+	}
+	if got := letras.intersecao(novaClasse(Intervalo{'k', 'y'})); !reflect.DeepEqual(got, ClasseSimbolos{{'k', 'l'}, {'x', 'y'}}) {
+		t.Errorf("intersecao() = %v", got)
+	}
+	if got := letras.diferenca(novaClasse(Intervalo{'b', 'k'})); !reflect.DeepEqual(got, ClasseSimbolos{{'a', 'a'}, {'l', 'l'}, {'x', 'z'}}) {
+		t.Errorf("diferenca() = %v", got)
+	}
+	if got := letras.complemento().complemento(); !reflect.DeepEqual(got, letras) {
+		t.Errorf("complemento() duas vezes = %v, want %v", got, letras)
+	}
+	if got := classeQualquer().diferenca(classeRuna('\n')).String(); got != `[^\x{a}]` {
+		t.Errorf("String() do complemento = %q", got)
+	}
+	if got := classeQualquer().tamanho(); got != unicode.MaxRune+1 {
+		t.Errorf("tamanho() = %d", got)
+	}
+}
+
+func TestParticionar(t *testing.T) {
+	atomos, pertence := particionar([]ClasseSimbolos{
+		novaClasse(Intervalo{'a', 'z'}),
+		novaClasse(Intervalo{'a', 'f'}, Intervalo{'0', '9'}),
+		novaClasse(Intervalo{'x', 'x'}, Intervalo{'m', 'm'}),
+	})
+	expectedAtomos := []ClasseSimbolos{
+		{{'0', '9'}},
+		{{'a', 'f'}},
+		{{'g', 'l'}, {'n', 'w'}, {'y', 'z'}},
+		{{'m', 'm'}, {'x', 'x'}},
+	}
+	expectedPertence := [][]int{{1}, {0, 1}, {0}, {0, 2}}
+	if !reflect.DeepEqual(atomos, expectedAtomos) || !reflect.DeepEqual(pertence, expectedPertence) {
+		t.Errorf("particionar() = %v, %v, want %v, %v", atomos, pertence, expectedAtomos, expectedPertence)
+	}
+}
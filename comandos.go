@@ -0,0 +1,102 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// executarComando trata os subcomandos de linha de comando e retorna o código de saída do processo.
+// Sem argumentos, main abre o menu interativo.
+func executarComando(args []string, saida io.Writer) int {
+	switch args[0] {
+	case "corrigir":
+		return comandoCorrigir(args[1:], saida)
+	case "testar":
+		return comandoTestar(args[1:], saida)
+	default:
+		fmt.Fprintf(os.Stderr, "Comando desconhecido: %s\n", args[0])
+		fmt.Fprintln(os.Stderr, "Uso: automatoFinitoGeral [corrigir|testar] ...")
+		return 2
+	}
+}
+
+func comandoCorrigir(args []string, saida io.Writer) int {
+	flags := flag.NewFlagSet("corrigir", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Uso: automatoFinitoGeral corrigir [opções] referencia.json submissao.json")
+		flags.PrintDefaults()
+	}
+	var cfg ConfiguracaoCorrecao
+	flags.BoolVar(&cfg.ExigirAFD, "afd", false, "exige que a submissão seja determinística")
+	flags.IntVar(&cfg.MaxEstados, "max-estados", 0, "número máximo de estados da submissão (0: sem limite)")
+	flags.BoolVar(&cfg.ExigirMesmoAlfabeto, "mesmo-alfabeto", false, "exige o mesmo alfabeto da referência")
+	flags.IntVar(&cfg.MaxContraexemplos, "contraexemplos", 5, "contraexemplos listados por categoria")
+	comoJSON := flags.Bool("json", false, "gera o relatório em JSON")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+
+	referencia, err := carregarAutomato(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Erro ao carregar a referência:", err)
+		return 2
+	}
+	submissao, err := carregarAutomato(flags.Arg(1))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Erro ao carregar a submissão:", err)
+		return 2
+	}
+
+	relatorio := corrigir(&referencia, &submissao, cfg)
+	if *comoJSON {
+		conteudo, err := relatorio.paraJSON()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Erro:", err)
+			return 2
+		}
+		fmt.Fprintln(saida, string(conteudo))
+	} else {
+		fmt.Fprint(saida, relatorio)
+	}
+	return 0
+}
+
+// comandoTestar roda uma suíte de testes contra o autômato e sai com 1 se algum caso falhar.
+func comandoTestar(args []string, saida io.Writer) int {
+	if len(args) != 2 {
+		fmt.Fprintln(os.Stderr, "Uso: automatoFinitoGeral testar automato.json suite.txt")
+		return 2
+	}
+	AF, err := carregarAutomato(args[0])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Erro ao carregar o autômato:", err)
+		return 2
+	}
+	conteudo, err := os.ReadFile(args[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Erro ao ler a suíte:", err)
+		return 2
+	}
+	casos, err := lerSuiteTestes(string(conteudo))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Erro na suíte %s: %v\n", args[1], err)
+		return 2
+	}
+
+	resultado, err := AF.executarSuite(casos)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Erro na suíte %s: %v\n", args[1], err)
+		return 2
+	}
+	fmt.Fprint(saida, resultado)
+	if len(resultado.Falhas) > 0 {
+		return 1
+	}
+	return 0
+}
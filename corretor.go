@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+// ConfiguracaoCorrecao define as restrições estruturais exigidas da submissão.
+type ConfiguracaoCorrecao struct {
+	ExigirAFD           bool
+	MaxEstados          int // 0: sem limite
+	ExigirMesmoAlfabeto bool
+	MaxContraexemplos   int // por categoria; 0 usa 5
+	ComprimentoNota     int // cadeias até este tamanho entram na nota parcial; 0 usa 8
+}
+
+type ResultadoRestricao struct {
+	Nome     string `json:"nome"`
+	Aprovada bool   `json:"aprovada"`
+	Detalhe  string `json:"detalhe,omitempty"`
+}
+
+// RelatorioCorrecao é o resultado da comparação de uma submissão com a referência.
+type RelatorioCorrecao struct {
+	Restricoes      []ResultadoRestricao `json:"restricoes"`
+	Equivalente     bool                 `json:"equivalente"`
+	FalsosAceites   []string             `json:"falsosAceites"`   // aceitas pela submissão, rejeitadas pela referência
+	FalsasRejeicoes []string             `json:"falsasRejeicoes"` // rejeitadas pela submissão, aceitas pela referência
+	Concordancia    float64              `json:"concordancia"`    // fração das cadeias até ComprimentoNota classificadas igual
+	Nota            float64              `json:"nota"`
+}
+
+// Pesos da nota: a linguagem vale pesoLinguagem pontos e as restrições ativas dividem o restante.
+// Sem restrições ativas, a linguagem vale a nota toda.
+const (
+	notaMaxima    = 100.0
+	pesoLinguagem = 70.0
+)
+
+// corrigir compara a submissão com a referência. Os contraexemplos são os menores (em tamanho e
+// depois em ordem alfabética) de cada categoria. Quando as linguagens diferem, a parte da
+// linguagem na nota é proporcional à concordância nas cadeias até ComprimentoNota.
+func corrigir(referencia, submissao *AutomatoFinito, cfg ConfiguracaoCorrecao) RelatorioCorrecao {
+	if cfg.MaxContraexemplos <= 0 {
+		cfg.MaxContraexemplos = 5
+	}
+	if cfg.ComprimentoNota <= 0 {
+		cfg.ComprimentoNota = 8
+	}
+
+	relatorio := RelatorioCorrecao{FalsosAceites: []string{}, FalsasRejeicoes: []string{}}
+	if cfg.ExigirAFD {
+		restricao := ResultadoRestricao{Nome: "AFD", Aprovada: submissao.ehDeterministico()}
+		if !restricao.Aprovada {
+			restricao.Detalhe = "a submissão tem transições épsilon ou mais de um destino por (estado, símbolo)"
+		}
+		relatorio.Restricoes = append(relatorio.Restricoes, restricao)
+	}
+	if cfg.MaxEstados > 0 {
+		restricao := ResultadoRestricao{
+			Nome:     fmt.Sprintf("no máximo %d estados", cfg.MaxEstados),
+			Aprovada: len(submissao.Estados) <= cfg.MaxEstados,
+		}
+		if !restricao.Aprovada {
+			restricao.Detalhe = fmt.Sprintf("a submissão tem %d estados", len(submissao.Estados))
+		}
+		relatorio.Restricoes = append(relatorio.Restricoes, restricao)
+	}
+	if cfg.ExigirMesmoAlfabeto {
+		alfabetoReferencia := slices.Sorted(slices.Values(referencia.Alfabeto))
+		alfabetoSubmissao := slices.Sorted(slices.Values(submissao.Alfabeto))
+		restricao := ResultadoRestricao{
+			Nome:     "mesmo alfabeto",
+			Aprovada: slices.Equal(slices.Compact(alfabetoReferencia), slices.Compact(alfabetoSubmissao)),
+		}
+		if !restricao.Aprovada {
+			restricao.Detalhe = fmt.Sprintf("esperado %q, encontrado %q", referencia.Alfabeto, submissao.Alfabeto)
+		}
+		relatorio.Restricoes = append(relatorio.Restricoes, restricao)
+	}
+
+	falsosAceites := produto(submissao, referencia, func(s, r bool) bool { return s && !r })
+	falsasRejeicoes := produto(submissao, referencia, func(s, r bool) bool { return !s && r })
+	relatorio.FalsosAceites = append(relatorio.FalsosAceites, falsosAceites.primeirasAceitas(cfg.MaxContraexemplos)...)
+	relatorio.FalsasRejeicoes = append(relatorio.FalsasRejeicoes, falsasRejeicoes.primeirasAceitas(cfg.MaxContraexemplos)...)
+	relatorio.Equivalente = len(relatorio.FalsosAceites) == 0 && len(relatorio.FalsasRejeicoes) == 0
+
+	relatorio.Concordancia = 1
+	if !relatorio.Equivalente {
+		diferenca := produto(submissao, referencia, func(s, r bool) bool { return s != r })
+		contagem := diferenca.tabelaContagem(cfg.ComprimentoNota)
+		discordantes, total := big.NewInt(0), big.NewInt(0)
+		base := big.NewInt(int64(len(diferenca.Alfabeto)))
+		for k := 0; k <= cfg.ComprimentoNota; k++ {
+			discordantes.Add(discordantes, contagem[k][diferenca.EstadoInicial])
+			total.Add(total, new(big.Int).Exp(base, big.NewInt(int64(k)), nil))
+		}
+		fracao, _ := new(big.Rat).SetFrac(discordantes, total).Float64()
+		relatorio.Concordancia = 1 - fracao
+	}
+
+	pesoRestricoes := notaMaxima - pesoLinguagem
+	pesoLinguagemEfetivo := pesoLinguagem
+	if len(relatorio.Restricoes) == 0 {
+		pesoLinguagemEfetivo = notaMaxima
+	}
+	relatorio.Nota = pesoLinguagemEfetivo * relatorio.Concordancia
+	if !relatorio.Equivalente {
+		// Mesmo com concordância alta, uma linguagem errada nunca recebe a parte toda
+		relatorio.Nota = min(relatorio.Nota, pesoLinguagemEfetivo*0.9)
+	}
+	for _, restricao := range relatorio.Restricoes {
+		if restricao.Aprovada {
+			relatorio.Nota += pesoRestricoes / float64(len(relatorio.Restricoes))
+		}
+	}
+	return relatorio
+}
+
+// formatarCadeia mostra a cadeia entre aspas, ou ε para a cadeia vazia.
+func formatarCadeia(cadeia string) string {
+	if cadeia == "" {
+		return string(epsilonRune)
+	}
+	return fmt.Sprintf("%q", cadeia)
+}
+
+func (R RelatorioCorrecao) String() string {
+	var sb strings.Builder
+	if len(R.Restricoes) > 0 {
+		sb.WriteString("Restrições:\n")
+		for _, restricao := range R.Restricoes {
+			situacao := "ok"
+			if !restricao.Aprovada {
+				situacao = "falhou"
+			}
+			fmt.Fprintf(&sb, "  [%s] %s", situacao, restricao.Nome)
+			if restricao.Detalhe != "" {
+				fmt.Fprintf(&sb, ": %s", restricao.Detalhe)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if R.Equivalente {
+		sb.WriteString("Linguagem: equivalente à referência\n")
+	} else {
+		fmt.Fprintf(&sb, "Linguagem: diferente da referência (concordância de %.1f%%)\n", 100*R.Concordancia)
+		listar := func(titulo string, cadeias []string) {
+			if len(cadeias) == 0 {
+				return
+			}
+			formatadas := make([]string, len(cadeias))
+			for i, cadeia := range cadeias {
+				formatadas[i] = formatarCadeia(cadeia)
+			}
+			fmt.Fprintf(&sb, "  %s: %s\n", titulo, strings.Join(formatadas, ", "))
+		}
+		listar("Falsos aceites (aceitas pela submissão, rejeitadas pela referência)", R.FalsosAceites)
+		listar("Falsas rejeições (rejeitadas pela submissão, aceitas pela referência)", R.FalsasRejeicoes)
+	}
+	fmt.Fprintf(&sb, "Nota: %.1f/%.0f\n", R.Nota, notaMaxima)
+	return sb.String()
+}
+
+func (R RelatorioCorrecao) paraJSON() ([]byte, error) {
+	return json.MarshalIndent(R, "", "  ")
+}
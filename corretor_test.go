@@ -0,0 +1,107 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// referenciaTerminaAB é o AFD mínimo de "termina com ab".
+var referenciaTerminaAB = AutomatoFinito{
+	Estados:  []string{"q0", "q1", "q2"},
+	Alfabeto: []rune{'a', 'b'},
+	Transicoes: map[string]map[rune][]string{
+		"q0": {'a': {"q1"}, 'b': {"q0"}},
+		"q1": {'a': {"q1"}, 'b': {"q2"}},
+		"q2": {'a': {"q1"}, 'b': {"q0"}},
+	},
+	EstadoInicial: "q0",
+	EstadosFinais: []string{"q2"},
+}
+
+func TestCorrigirEquivalente(t *testing.T) {
+	// AFN correto, mas não determinístico e com mais estados do que o permitido
+	submissao := AutomatoFinito{
+		Estados:  []string{"s0", "s1", "s2", "s3"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"s0": {'a': {"s0", "s1"}, 'b': {"s0"}},
+			"s1": {'b': {"s2"}},
+		},
+		EstadoInicial: "s0",
+		EstadosFinais: []string{"s2"},
+	}
+
+	relatorio := corrigir(&referenciaTerminaAB, &submissao, ConfiguracaoCorrecao{ExigirAFD: true, MaxEstados: 3, ExigirMesmoAlfabeto: true})
+	if !relatorio.Equivalente {
+		t.Errorf("Equivalente = false, want true (falsos aceites %q, falsas rejeições %q)", relatorio.FalsosAceites, relatorio.FalsasRejeicoes)
+	}
+	aprovadas := 0
+	for _, restricao := range relatorio.Restricoes {
+		if restricao.Aprovada {
+			aprovadas++
+		}
+	}
+	if len(relatorio.Restricoes) != 3 || aprovadas != 1 {
+		t.Errorf("Restricoes = %+v, want 3 restrições com 1 aprovada", relatorio.Restricoes)
+	}
+	if relatorio.Nota != 80 {
+		t.Errorf("Nota = %v, want 80", relatorio.Nota)
+	}
+}
+
+func TestCorrigirContraexemplos(t *testing.T) {
+	// Aceita as cadeias que terminam com 'b'
+	submissao := AutomatoFinito{
+		Estados:  []string{"s0", "s1"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"s0": {'a': {"s0"}, 'b': {"s1"}},
+			"s1": {'a': {"s0"}, 'b': {"s1"}},
+		},
+		EstadoInicial: "s0",
+		EstadosFinais: []string{"s1"},
+	}
+
+	relatorio := corrigir(&referenciaTerminaAB, &submissao, ConfiguracaoCorrecao{MaxContraexemplos: 3})
+	if relatorio.Equivalente {
+		t.Fatalf("Equivalente = true, want false")
+	}
+	if expected := []string{"b", "bb", "abb"}; !slices.Equal(relatorio.FalsosAceites, expected) {
+		t.Errorf("FalsosAceites = %q, want %q", relatorio.FalsosAceites, expected)
+	}
+	if len(relatorio.FalsasRejeicoes) != 0 {
+		t.Errorf("FalsasRejeicoes = %q, want nenhuma", relatorio.FalsasRejeicoes)
+	}
+	if relatorio.Nota >= 90 || relatorio.Nota <= 0 {
+		t.Errorf("Nota = %v, want parcial", relatorio.Nota)
+	}
+
+	texto := relatorio.String()
+	for _, trecho := range []string{"diferente da referência", `Falsos aceites`, `"b", "bb", "abb"`, "Nota:"} {
+		if !strings.Contains(texto, trecho) {
+			t.Errorf("relatório sem %q:\n%s", trecho, texto)
+		}
+	}
+}
+
+func TestComandoCorrigir(t *testing.T) {
+	diretorio := t.TempDir()
+	referencia := filepath.Join(diretorio, "referencia.json")
+	if err := salvarAutomato(referencia, &referenciaTerminaAB); err != nil {
+		t.Fatal(err)
+	}
+
+	var saida strings.Builder
+	if codigo := executarComando([]string{"corrigir", "-json", referencia, referencia}, &saida); codigo != 0 {
+		t.Fatalf("código de saída = %d, want 0", codigo)
+	}
+	if !strings.Contains(saida.String(), `"equivalente": true`) || !strings.Contains(saida.String(), `"nota": 100`) {
+		t.Errorf("saída JSON inesperada:\n%s", saida.String())
+	}
+
+	if codigo := executarComando([]string{"corrigir", referencia, filepath.Join(diretorio, "inexistente.json")}, &saida); codigo != 2 {
+		t.Errorf("código de saída com arquivo inexistente = %d, want 2", codigo)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"slices"
+	"strings"
+)
+
+// nomeConjunto nomeia um estado do AFD pelo conjunto de estados do AFN que ele representa, em ordem
+// natural, ex.: "{q2,q10}".
+func nomeConjunto(estados []string) string {
+	ordenados := slices.Clone(estados)
+	ordenarEstados(ordenados)
+	return "{" + strings.Join(slices.Compact(ordenados), ",") + "}"
+}
+
+// determinizar aplica a construção de subconjuntos, levando em conta as transições épsilon. Só os
+// subconjuntos alcançáveis são gerados e o conjunto vazio é omitido, de forma que o AFD resultante
+// pode ser parcial.
+func (AF *Automato[S]) determinizar() Automato[S] {
+	simbolos := AF.simbolosOrdenados()
+	AFD := Automato[S]{Alfabeto: slices.Clone(simbolos)}
+
+	inicial := AF.epsilonClosure([]string{AF.EstadoInicial})
+	ordenarEstados(inicial)
+	AFD.adicionarEstado(nomeConjunto(inicial))
+	AFD.adicionarEstadoInicial(nomeConjunto(inicial))
+
+	fila := [][]string{inicial}
+	for len(fila) > 0 {
+		conjunto := fila[0]
+		fila = fila[1:]
+		nome := nomeConjunto(conjunto)
+
+		if slices.ContainsFunc(conjunto, func(estado string) bool { return slices.Contains(AF.EstadosFinais, estado) }) {
+			AFD.adicionarEstadoFinal(nome)
+		}
+
+		for _, simbolo := range simbolos {
+			var alcancados []string
+			for _, estado := range conjunto {
+				alcancados = append(alcancados, AF.Transicoes[estado][simbolo]...)
+			}
+			if len(alcancados) == 0 {
+				continue
+			}
+			proximo := AF.epsilonClosure(alcancados)
+			ordenarEstados(proximo)
+			nomeProximo := nomeConjunto(proximo)
+			if !slices.Contains(AFD.Estados, nomeProximo) {
+				AFD.adicionarEstado(nomeProximo)
+				fila = append(fila, proximo)
+			}
+			AFD.adicionarTransicao(nome, simbolo, nomeProximo)
+		}
+	}
+	return AFD
+}
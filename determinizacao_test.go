@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestDeterminizar(t *testing.T) {
+	// a*b com épsilon
+	nfa := AutomatoFinito{
+		Estados:  []string{"q_start", "q_a_loop", "q_b_trans", "q_final"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"q_a_loop":  {'a': {"q_a_loop"}},
+			"q_b_trans": {'b': {"q_final"}},
+		},
+		TransicoesEpsilon: map[string][]string{
+			"q_start":  {"q_a_loop"},
+			"q_a_loop": {"q_b_trans"},
+		},
+		EstadoInicial: "q_start",
+		EstadosFinais: []string{"q_final"},
+	}
+
+	dfa := nfa.determinizar()
+	if !dfa.ehDeterministico() {
+		t.Fatalf("determinizar() gerou um autômato não determinístico: %v", dfa.Transicoes)
+	}
+	if expected := "{q_a_loop,q_b_trans,q_start}"; dfa.EstadoInicial != expected {
+		t.Errorf("EstadoInicial = %s, want %s", dfa.EstadoInicial, expected)
+	}
+	if len(dfa.Estados) != 3 {
+		t.Errorf("determinizar() gerou %d estados, want 3: %v", len(dfa.Estados), dfa.Estados)
+	}
+
+	for _, cadeia := range []string{"", "a", "b", "ab", "aab", "ba", "abb", "acb"} {
+		nfa.adicionarCadeia(cadeia)
+		dfa.adicionarCadeia(cadeia)
+		if got, want := dfa.funcionamento(), nfa.funcionamento(); got != want {
+			t.Errorf("cadeia \"%s\": AFD = %v, AFN = %v", cadeia, got, want)
+		}
+	}
+}
@@ -0,0 +1,379 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Formato texto (.af) para escrever autômatos à mão, uma declaração por linha:
+//
+//	# comentário até o fim da linha
+//	states q0 q1 q2       (opcional: fixa a ordem e inclui estados sem transições)
+//	alphabet a b          (opcional: se presente, as arestas só podem usar estes símbolos)
+//	start q0
+//	final q2
+//	q0 -a,b-> q0          (um símbolo por item, separados por vírgula)
+//	q0 -[a-z0-9]-> q1     (intervalos entre colchetes)
+//	q1 -ε-> q2 q3         (ε ou eps para épsilon; vários destinos)
+//
+// Nomes e símbolos aceitam os escapes de decodificarCadeia; nos rótulos, "\," "\-" "\[" e "\]"
+// escrevem os próprios caracteres e "\ε" é o símbolo ε, não uma transição épsilon.
+
+// ErroDSL é um erro de sintaxe com a posição (linha e coluna, a partir de 1, contadas em caracteres).
+type ErroDSL struct {
+	Linha, Coluna int
+	Mensagem      string
+}
+
+func (e *ErroDSL) Error() string {
+	return fmt.Sprintf("linha %d, coluna %d: %s", e.Linha, e.Coluna, e.Mensagem)
+}
+
+// tokenDSL é uma palavra da linha com a coluna em que começa.
+type tokenDSL struct {
+	texto  string
+	coluna int
+}
+
+// separarTokens divide a linha em palavras separadas por espaços, parando num '#' que inicia palavra.
+func separarTokens(linha string) []tokenDSL {
+	var tokens []tokenDSL
+	var atual []rune
+	inicio := 0
+	for i, r := range append([]rune(linha), ' ') {
+		if !unicode.IsSpace(r) {
+			if len(atual) == 0 {
+				if r == '#' {
+					break
+				}
+				inicio = i
+			}
+			atual = append(atual, r)
+			continue
+		}
+		if len(atual) > 0 {
+			tokens = append(tokens, tokenDSL{string(atual), inicio + 1})
+			atual = nil
+		}
+	}
+	return tokens
+}
+
+// lerDSL interpreta o formato texto. Os estados ficam na ordem da linha states seguida da ordem em
+// que aparecem; o alfabeto, na ordem da linha alphabet seguida da ordem das arestas.
+func lerDSL(texto string) (AutomatoFinito, error) {
+	AF := AutomatoFinito{}
+	alfabetoDeclarado := false
+	linhaInicial := 0
+
+	for i, linha := range strings.Split(texto, "\n") {
+		numero := i + 1
+		erro := func(coluna int, formato string, args ...any) error {
+			return &ErroDSL{numero, coluna, fmt.Sprintf(formato, args...)}
+		}
+		nome := func(token tokenDSL) (string, error) {
+			estado, err := decodificarCadeia(token.texto)
+			if err != nil {
+				return "", erro(token.coluna, "%v", err)
+			}
+			if estado == "" {
+				return "", erro(token.coluna, "nome de estado vazio")
+			}
+			if !slices.Contains(AF.Estados, estado) {
+				AF.adicionarEstado(estado)
+			}
+			return estado, nil
+		}
+
+		tokens := separarTokens(linha)
+		if len(tokens) == 0 {
+			continue
+		}
+		switch tokens[0].texto {
+		case "states", "final":
+			if len(tokens) == 1 {
+				return AutomatoFinito{}, erro(tokens[0].coluna, "%s precisa de ao menos um estado", tokens[0].texto)
+			}
+			for _, token := range tokens[1:] {
+				estado, err := nome(token)
+				if err != nil {
+					return AutomatoFinito{}, err
+				}
+				if tokens[0].texto == "final" && !slices.Contains(AF.EstadosFinais, estado) {
+					AF.adicionarEstadoFinal(estado)
+				}
+			}
+		case "start":
+			if len(tokens) != 2 {
+				return AutomatoFinito{}, erro(tokens[0].coluna, "start precisa de exatamente um estado")
+			}
+			if linhaInicial != 0 {
+				return AutomatoFinito{}, erro(tokens[0].coluna, "estado inicial já definido na linha %d", linhaInicial)
+			}
+			estado, err := nome(tokens[1])
+			if err != nil {
+				return AutomatoFinito{}, err
+			}
+			AF.adicionarEstadoInicial(estado)
+			linhaInicial = numero
+		case "alphabet":
+			alfabetoDeclarado = true
+			for _, token := range tokens[1:] {
+				simbolos, epsilon, err := lerRotulo(token.texto, token.coluna)
+				if err != nil {
+					return AutomatoFinito{}, erro(err.Coluna, "%s", err.Mensagem)
+				}
+				if epsilon {
+					return AutomatoFinito{}, erro(token.coluna, "épsilon não pode fazer parte do alfabeto (use \\ε para o símbolo ε)")
+				}
+				for _, simbolo := range simbolos {
+					if !slices.Contains(AF.Alfabeto, simbolo) {
+						AF.adicionarAlfabeto(simbolo)
+					}
+				}
+			}
+		default:
+			if len(tokens) < 3 {
+				return AutomatoFinito{}, erro(tokens[0].coluna, "esperado \"ORIGEM -SÍMBOLOS-> DESTINO\" ou uma declaração (states, alphabet, start, final)")
+			}
+			aresta := tokens[1]
+			if !strings.HasPrefix(aresta.texto, "-") || !strings.HasSuffix(aresta.texto, "->") || len(aresta.texto) < 4 {
+				return AutomatoFinito{}, erro(aresta.coluna, "esperado uma aresta como -a-> ou -a,b->, encontrado %q", aresta.texto)
+			}
+			simbolos, epsilon, errRotulo := lerRotulo(strings.TrimSuffix(aresta.texto[1:], "->"), aresta.coluna+1)
+			if errRotulo != nil {
+				return AutomatoFinito{}, erro(errRotulo.Coluna, "%s", errRotulo.Mensagem)
+			}
+			for _, simbolo := range simbolos {
+				if slices.Contains(AF.Alfabeto, simbolo) {
+					continue
+				}
+				if alfabetoDeclarado {
+					return AutomatoFinito{}, erro(aresta.coluna+1, "símbolo %q fora do alfabeto declarado", simbolo)
+				}
+				AF.adicionarAlfabeto(simbolo)
+			}
+
+			origem, err := nome(tokens[0])
+			if err != nil {
+				return AutomatoFinito{}, err
+			}
+			for _, token := range tokens[2:] {
+				destino, err := nome(token)
+				if err != nil {
+					return AutomatoFinito{}, err
+				}
+				for _, simbolo := range simbolos {
+					if !slices.Contains(AF.Transicoes[origem][simbolo], destino) {
+						AF.adicionarTransicao(origem, simbolo, destino)
+					}
+				}
+				if epsilon && !slices.Contains(AF.TransicoesEpsilon[origem], destino) {
+					AF.adicionarTransicaoEpsilon(origem, destino)
+				}
+			}
+		}
+	}
+
+	if linhaInicial == 0 {
+		return AutomatoFinito{}, fmt.Errorf("falta a linha \"start\" com o estado inicial")
+	}
+	return AF, nil
+}
+
+// lerRotulo interpreta o rótulo de uma aresta (sem os traços) ou um item da linha alphabet. coluna
+// é a coluna do primeiro caractere do rótulo, usada nos erros. Um item "ε" ou "eps" é épsilon, que
+// é informado à parte dos símbolos.
+func lerRotulo(rotulo string, coluna int) (simbolos []rune, epsilon bool, erro *ErroDSL) {
+	entrada := []rune(rotulo)
+	adicionar := func(simbolo rune) {
+		if !slices.Contains(simbolos, simbolo) {
+			simbolos = append(simbolos, simbolo)
+		}
+	}
+	// caractere lê um caractere, tratando escapes, e retorna também quantas posições ocupou.
+	caractere := func(i int) (rune, int, *ErroDSL) {
+		if entrada[i] == epsilonRune {
+			return 0, 0, &ErroDSL{0, coluna + i, "ε só pode aparecer sozinho no rótulo (use \\ε para o símbolo ε)"}
+		}
+		if entrada[i] != '\\' {
+			return entrada[i], 1, nil
+		}
+		if i+1 == len(entrada) {
+			return 0, 0, &ErroDSL{0, coluna + i, "barra invertida sem caractere"}
+		}
+		tamanho := 2
+		if entrada[i+1] == 'u' {
+			tamanho = 6
+		}
+		decodificado, err := decodificarCadeia(string(entrada[i:min(i+tamanho, len(entrada))]))
+		if err != nil {
+			return 0, 0, &ErroDSL{0, coluna + i, err.Error()}
+		}
+		r := []rune(decodificado)
+		if len(r) != 1 {
+			return 0, 0, &ErroDSL{0, coluna + i, fmt.Sprintf("escape %q não é um caractere", string(entrada[i:min(i+tamanho, len(entrada))]))}
+		}
+		return r[0], tamanho, nil
+	}
+
+	if len(entrada) == 0 {
+		return nil, false, &ErroDSL{0, coluna, "rótulo vazio"}
+	}
+	for inicio := 0; inicio <= len(entrada); {
+		fim, colchete := inicio, false
+		for fim < len(entrada) && (colchete || entrada[fim] != ',') {
+			switch entrada[fim] {
+			case '\\':
+				fim++
+			case '[':
+				colchete = true
+			case ']':
+				colchete = false
+			}
+			fim++
+		}
+		fim = min(fim, len(entrada))
+		item := string(entrada[inicio:fim])
+
+		switch {
+		case item == "":
+			return nil, false, &ErroDSL{0, coluna + inicio, "item vazio no rótulo"}
+		case item == string(epsilonRune) || item == "eps":
+			epsilon = true
+		case entrada[inicio] == '[':
+			if entrada[fim-1] != ']' || fim-inicio < 3 {
+				return nil, false, &ErroDSL{0, coluna + inicio, fmt.Sprintf("intervalo %q sem ']' ou vazio", item)}
+			}
+			for i := inicio + 1; i < fim-1; {
+				de, tamanho, err := caractere(i)
+				if err != nil {
+					return nil, false, err
+				}
+				i += tamanho
+				if i < fim-2 && entrada[i] == '-' {
+					ate, tamanho, err := caractere(i + 1)
+					if err != nil {
+						return nil, false, err
+					}
+					if ate < de {
+						return nil, false, &ErroDSL{0, coluna + i - 1, fmt.Sprintf("intervalo invertido %c-%c", de, ate)}
+					}
+					for r := de; r <= ate; r++ {
+						adicionar(r)
+					}
+					i += 1 + tamanho
+					continue
+				}
+				adicionar(de)
+			}
+		default:
+			simbolo, tamanho, err := caractere(inicio)
+			if err != nil {
+				return nil, false, err
+			}
+			if inicio+tamanho != fim {
+				return nil, false, &ErroDSL{0, coluna + inicio, fmt.Sprintf("%q não é um símbolo: use um caractere, ε ou [intervalo]", item)}
+			}
+			adicionar(simbolo)
+		}
+		inicio = fim + 1
+	}
+	return simbolos, epsilon, nil
+}
+
+// codificarSimboloDSL escreve o símbolo de forma que lerRotulo o leia de volta.
+func codificarSimboloDSL(simbolo rune) string {
+	if strings.ContainsRune(",-[]#", simbolo) {
+		return "\\" + string(simbolo)
+	}
+	return codificarCadeia(string(simbolo))
+}
+
+// codificarEstadoDSL escreve o nome do estado sem espaços e sem confundi-lo com uma palavra-chave
+// ou um comentário.
+func codificarEstadoDSL(estado string) string {
+	texto := codificarCadeia(estado)
+	if strings.HasPrefix(texto, "#") {
+		return "\\" + texto
+	}
+	if slices.Contains([]string{"states", "alphabet", "start", "final"}, texto) {
+		// Escapa a primeira letra cujo escape não tem significado especial (\s, \t e \u têm)
+		i := strings.IndexFunc(texto, func(r rune) bool { return !strings.ContainsRune("stu", r) })
+		return texto[:i] + "\\" + texto[i:]
+	}
+	return texto
+}
+
+// formatarRotulo junta os símbolos de uma aresta, em ordem, escrevendo sequências de três ou mais
+// caracteres consecutivos como intervalo. Épsilon, se houver, vem primeiro.
+func formatarRotulo(simbolos []rune, epsilon bool) string {
+	var itens []string
+	if epsilon {
+		itens = append(itens, string(epsilonRune))
+	}
+	simbolos = slices.Sorted(slices.Values(simbolos))
+	for i := 0; i < len(simbolos); {
+		j := i
+		for j+1 < len(simbolos) && simbolos[j+1] == simbolos[j]+1 {
+			j++
+		}
+		if j-i >= 2 {
+			itens = append(itens, "["+codificarSimboloDSL(simbolos[i])+"-"+codificarSimboloDSL(simbolos[j])+"]")
+			i = j + 1
+			continue
+		}
+		itens = append(itens, codificarSimboloDSL(simbolos[i]))
+		i++
+	}
+	return strings.Join(itens, ",")
+}
+
+// paraDSL escreve o autômato no formato texto, com uma aresta por par (origem, destino) na ordem
+// de Estados. Como na tabela de transições, uma transição com estado ou símbolo não declarado é um
+// erro: ela seria perdida ou produziria um arquivo que lerDSL rejeita.
+func paraDSL(AF *AutomatoFinito) (string, error) {
+	if err := verificarDeclarados(AF); err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	linha := func(palavra string, itens []string) {
+		if len(itens) > 0 {
+			fmt.Fprintf(&sb, "%s %s\n", palavra, strings.Join(itens, " "))
+		}
+	}
+	var estados, alfabeto, finais []string
+	for _, estado := range AF.Estados {
+		estados = append(estados, codificarEstadoDSL(estado))
+	}
+	for _, simbolo := range AF.Alfabeto {
+		alfabeto = append(alfabeto, codificarSimboloDSL(simbolo))
+	}
+	for _, final := range AF.EstadosFinais {
+		finais = append(finais, codificarEstadoDSL(final))
+	}
+	linha("states", estados)
+	linha("alphabet", alfabeto)
+	if AF.EstadoInicial != "" {
+		linha("start", []string{codificarEstadoDSL(AF.EstadoInicial)})
+	}
+	linha("final", finais)
+
+	for _, origem := range AF.Estados {
+		for _, destino := range AF.Estados {
+			var simbolos []rune
+			for simbolo, destinos := range AF.Transicoes[origem] {
+				if slices.Contains(destinos, destino) {
+					simbolos = append(simbolos, simbolo)
+				}
+			}
+			epsilon := slices.Contains(AF.TransicoesEpsilon[origem], destino)
+			if len(simbolos) > 0 || epsilon {
+				fmt.Fprintf(&sb, "%s -%s-> %s\n", codificarEstadoDSL(origem), formatarRotulo(simbolos, epsilon), codificarEstadoDSL(destino))
+			}
+		}
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLerDSL(t *testing.T) {
+	texto := `# identificadores: letra seguida de letras ou dígitos
+states q0 q1
+start q0   # estado inicial
+final q1
+q0 -[a-c],_-> q1
+q1 -[a-c0-2],_-> q1
+q1 -ε-> erro
+`
+	AF, err := lerDSL(texto)
+	if err != nil {
+		t.Fatalf("lerDSL() erro inesperado: %v", err)
+	}
+	if expected := []string{"q0", "q1", "erro"}; !reflect.DeepEqual(AF.Estados, expected) {
+		t.Errorf("Estados = %q, want %q", AF.Estados, expected)
+	}
+	if expected := []rune("abc_012"); !reflect.DeepEqual(AF.Alfabeto, expected) {
+		t.Errorf("Alfabeto = %q, want %q", AF.Alfabeto, expected)
+	}
+	if AF.EstadoInicial != "q0" || !reflect.DeepEqual(AF.EstadosFinais, []string{"q1"}) {
+		t.Errorf("inicial %q, finais %q", AF.EstadoInicial, AF.EstadosFinais)
+	}
+	for _, tt := range []struct {
+		cadeia   string
+		expected bool
+	}{{"a", true}, {"b_2", true}, {"_", true}, {"", false}, {"0a", false}, {"ad", false}} {
+		AF.adicionarCadeia(tt.cadeia)
+		if got := AF.funcionamento(); got != tt.expected {
+			t.Errorf("cadeia %q: got %v, want %v", tt.cadeia, got, tt.expected)
+		}
+	}
+}
+
+func TestLerDSLErros(t *testing.T) {
+	tests := []struct {
+		name   string
+		texto  string
+		linha  int
+		coluna int
+		trecho string
+	}{
+		{"aresta malformada", "start q0\nq0 a q1", 2, 4, "esperado uma aresta"},
+		{"intervalo invertido", "start q0\nq0  -[z-a]-> q1", 2, 7, "intervalo invertido"},
+		{"símbolo com dois caracteres", "start q0\nq0 -a,bc-> q1", 2, 7, "\"bc\" não é um símbolo"},
+		{"item vazio", "start q0\nq0 -a,-> q1", 2, 7, "item vazio"},
+		{"fora do alfabeto", "alphabet a\nstart q0\nq0 -b-> q1", 3, 5, "fora do alfabeto"},
+		{"dois iniciais", "start q0\n\n  start q1", 3, 3, "já definido na linha 1"},
+		{"linha incompleta", "start q0\nq0 -a->", 2, 1, "esperado \"ORIGEM"},
+		{"épsilon no alfabeto", "alphabet a ε", 1, 12, "épsilon não pode fazer parte do alfabeto"},
+		{"ε sem escape no intervalo", "start q0\nq0 -[aε]-> q1", 2, 7, "use \\ε para o símbolo ε"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := lerDSL(tt.texto)
+			var erroDSL *ErroDSL
+			if !errors.As(err, &erroDSL) {
+				t.Fatalf("lerDSL() erro = %v, want *ErroDSL", err)
+			}
+			if erroDSL.Linha != tt.linha || erroDSL.Coluna != tt.coluna || !strings.Contains(erroDSL.Mensagem, tt.trecho) {
+				t.Errorf("lerDSL() erro = %v, want linha %d, coluna %d contendo %q", err, tt.linha, tt.coluna, tt.trecho)
+			}
+		})
+	}
+
+	if _, err := lerDSL("q0 -a-> q1\n"); err == nil || !strings.Contains(err.Error(), "start") {
+		t.Errorf("lerDSL() sem start: erro = %v", err)
+	}
+}
+
+func TestParaDSLIdaEVolta(t *testing.T) {
+	AF := AutomatoFinito{
+		Estados:  []string{"q0", "estado 1", "start", "#x"},
+		Alfabeto: []rune{'a', 'b', 'c', 'd', ',', '-', ' ', 'ε'},
+		Transicoes: map[string]map[rune][]string{
+			"q0":       {'a': {"q0", "estado 1"}, 'b': {"q0"}, 'c': {"q0"}, 'd': {"start"}, ',': {"#x"}},
+			"estado 1": {'-': {"start"}, ' ': {"start"}, 'ε': {"#x"}},
+		},
+		TransicoesEpsilon: map[string][]string{"estado 1": {"start"}},
+		EstadoInicial:     "q0",
+		EstadosFinais:     []string{"start", "#x"},
+	}
+	texto, err := paraDSL(&AF)
+	if err != nil {
+		t.Fatalf("paraDSL() erro inesperado: %v", err)
+	}
+	expected := `states q0 estado\s1 st\art \#x
+alphabet a b c d \, \- \s \ε
+start q0
+final st\art \#x
+q0 -[a-c]-> q0
+q0 -a-> estado\s1
+q0 -d-> st\art
+q0 -\,-> \#x
+estado\s1 -ε,\s,\--> st\art
+estado\s1 -\ε-> \#x
+`
+	if texto != expected {
+		t.Errorf("paraDSL() =\n%s\nwant\n%s", texto, expected)
+	}
+	relido, err := lerDSL(texto)
+	if err != nil {
+		t.Fatalf("lerDSL(paraDSL()) erro inesperado: %v", err)
+	}
+	if !reflect.DeepEqual(relido, AF) {
+		t.Errorf("lerDSL(paraDSL()) = %+v, want %+v", relido, AF)
+	}
+}
+
+func TestParaDSLNaoDeclarados(t *testing.T) {
+	tests := []struct {
+		transicoes map[string]map[rune][]string
+		trecho     string
+	}{
+		{map[string]map[rune][]string{"q0": {'b': {"q0"}}}, "transição q0,'b' --> q0: símbolo 'b' não está no alfabeto"},
+		{map[string]map[rune][]string{"q0": {'a': {"q9"}}}, "transição q0,'a' --> q9: estado 'q9' não está entre os estados"},
+	}
+	for _, tt := range tests {
+		AF := AutomatoFinito{
+			Estados:       []string{"q0"},
+			Alfabeto:      []rune{'a'},
+			Transicoes:    tt.transicoes,
+			EstadoInicial: "q0",
+		}
+		if _, err := paraDSL(&AF); err == nil || !strings.Contains(err.Error(), tt.trecho) {
+			t.Errorf("paraDSL(%v) erro = %v, want contendo %q", tt.transicoes, err, tt.trecho)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+)
+
+// removerEstado apaga o estado e todas as transições que saem ou chegam nele. Se ele era o inicial,
+// o autômato fica sem estado inicial.
+func (AF *Automato[S]) removerEstado(estado string) error {
+	if !slices.Contains(AF.Estados, estado) {
+		return fmt.Errorf("estado '%s' não existe", estado)
+	}
+	AF.Estados = slices.DeleteFunc(AF.Estados, func(e string) bool { return e == estado })
+	AF.EstadosFinais = slices.DeleteFunc(AF.EstadosFinais, func(e string) bool { return e == estado })
+	if AF.EstadoInicial == estado {
+		AF.EstadoInicial = ""
+	}
+	delete(AF.Transicoes, estado)
+	delete(AF.TransicoesEpsilon, estado)
+	for origem, destinos := range AF.TransicoesEpsilon {
+		destinos = slices.DeleteFunc(destinos, func(e string) bool { return e == estado })
+		if len(destinos) == 0 {
+			delete(AF.TransicoesEpsilon, origem)
+		} else {
+			AF.TransicoesEpsilon[origem] = destinos
+		}
+	}
+	for origem, m := range AF.Transicoes {
+		for simbolo, destinos := range m {
+			destinos = slices.DeleteFunc(destinos, func(e string) bool { return e == estado })
+			if len(destinos) == 0 {
+				delete(m, simbolo)
+			} else {
+				m[simbolo] = destinos
+			}
+		}
+		if len(m) == 0 {
+			delete(AF.Transicoes, origem)
+		}
+	}
+	return nil
+}
+
+// renomearEstado troca o nome do estado em Estados, Transicoes e TransicoesEpsilon (origens e
+// destinos), EstadoInicial e EstadosFinais, mantendo a posição do estado na lista.
+func (AF *Automato[S]) renomearEstado(antigo, novo string) error {
+	switch {
+	case !slices.Contains(AF.Estados, antigo):
+		return fmt.Errorf("estado '%s' não existe", antigo)
+	case novo == "":
+		return fmt.Errorf("nome do estado não pode ser vazio")
+	case antigo != novo && slices.Contains(AF.Estados, novo):
+		return fmt.Errorf("estado '%s' já existe", novo)
+	}
+	renomear := func(e string) string {
+		if e == antigo {
+			return novo
+		}
+		return e
+	}
+	for i, e := range AF.Estados {
+		AF.Estados[i] = renomear(e)
+	}
+	for i, e := range AF.EstadosFinais {
+		AF.EstadosFinais[i] = renomear(e)
+	}
+	AF.EstadoInicial = renomear(AF.EstadoInicial)
+	if m, ok := AF.Transicoes[antigo]; ok {
+		delete(AF.Transicoes, antigo)
+		AF.Transicoes[novo] = m
+	}
+	for _, m := range AF.Transicoes {
+		for _, destinos := range m {
+			for i, e := range destinos {
+				destinos[i] = renomear(e)
+			}
+		}
+	}
+	if destinos, ok := AF.TransicoesEpsilon[antigo]; ok {
+		delete(AF.TransicoesEpsilon, antigo)
+		AF.TransicoesEpsilon[novo] = destinos
+	}
+	for _, destinos := range AF.TransicoesEpsilon {
+		for i, e := range destinos {
+			destinos[i] = renomear(e)
+		}
+	}
+	return nil
+}
+
+// removerTransicao apaga o destino da transição (origem, simbolo), sem afetar os outros destinos.
+func (AF *Automato[S]) removerTransicao(origem string, simbolo S, destino string) error {
+	destinos := AF.Transicoes[origem][simbolo]
+	if !slices.Contains(destinos, destino) {
+		return fmt.Errorf("transição %s,'%s' --> %s não existe", origem, formatarSimbolo(simbolo), destino)
+	}
+	destinos = slices.DeleteFunc(destinos, func(e string) bool { return e == destino })
+	if len(destinos) == 0 {
+		delete(AF.Transicoes[origem], simbolo)
+		if len(AF.Transicoes[origem]) == 0 {
+			delete(AF.Transicoes, origem)
+		}
+	} else {
+		AF.Transicoes[origem][simbolo] = destinos
+	}
+	return nil
+}
+
+// removerTransicaoEpsilon apaga a transição épsilon de origem para destino.
+func (AF *Automato[S]) removerTransicaoEpsilon(origem string, destino string) error {
+	destinos := AF.TransicoesEpsilon[origem]
+	if !slices.Contains(destinos, destino) {
+		return fmt.Errorf("transição %s,ε --> %s não existe", origem, destino)
+	}
+	destinos = slices.DeleteFunc(destinos, func(e string) bool { return e == destino })
+	if len(destinos) == 0 {
+		delete(AF.TransicoesEpsilon, origem)
+	} else {
+		AF.TransicoesEpsilon[origem] = destinos
+	}
+	return nil
+}
+
+// alternarEstadoFinal torna o estado final se ele não era, e não final caso contrário. Retorna se o
+// estado ficou final.
+func (AF *Automato[S]) alternarEstadoFinal(estado string) (bool, error) {
+	if !slices.Contains(AF.Estados, estado) {
+		return false, fmt.Errorf("estado '%s' não existe", estado)
+	}
+	if slices.Contains(AF.EstadosFinais, estado) {
+		AF.EstadosFinais = slices.DeleteFunc(AF.EstadosFinais, func(e string) bool { return e == estado })
+		return false, nil
+	}
+	AF.adicionarEstadoFinal(estado)
+	return true, nil
+}
+
+// executarComandoUsuario executa o comando na sessão e informa o resultado.
+func executarComandoUsuario(sessao *Sessao, comando Comando, sucesso string) {
+	if err := sessao.executar(comando); err != nil {
+		fmt.Printf("Erro: %v.\n", err)
+		return
+	}
+	fmt.Println(sucesso)
+}
+
+// leituraArgumentos lê os argumentos de um comando, um prompt por argumento.
+func leituraArgumentos(prompts ...string) ([]string, bool) {
+	var args []string
+	for _, prompt := range prompts {
+		fmt.Print(prompt)
+		arg, ok := lerEntrada("")
+		if !ok {
+			return nil, false
+		}
+		args = append(args, arg)
+	}
+	return args, true
+}
+
+func edicaoAutomato(sessao *Sessao) {
+	AFUsuario := &sessao.Automato
+	for {
+		fmt.Println("\n--- Editar Autômato ---")
+		fmt.Println("1. Adicionar estado")
+		fmt.Println("2. Remover estado")
+		fmt.Println("3. Renomear estado")
+		fmt.Println("4. Adicionar transições")
+		fmt.Println("5. Remover transição")
+		fmt.Println("6. Alterar estado inicial")
+		fmt.Println("7. Marcar/desmarcar estado final")
+		fmt.Println("8. Exibir autômato")
+		fmt.Println("9. Testar cadeias")
+		fmt.Println("10. Desfazer")
+		fmt.Println("11. Refazer")
+		fmt.Println("12. Exibir histórico como script")
+		fmt.Println("13. Salvar histórico em arquivo")
+		fmt.Println("0. Voltar ao menu principal")
+		fmt.Print("Escolha uma opção: ")
+
+		escolha, ok := lerOpcao()
+		if !ok {
+			return
+		}
+		switch escolha {
+		case 1:
+			leituraEstados(sessao)
+		case 2:
+			if args, ok := leituraArgumentos("Estado a remover: "); ok {
+				executarComandoUsuario(sessao, Comando{"delstate", args}, fmt.Sprintf("Estado '%s' removido com suas transições.", args[0]))
+				if AFUsuario.EstadoInicial == "" {
+					fmt.Println("Atenção: o autômato está sem estado inicial.")
+				}
+			}
+		case 3:
+			if args, ok := leituraArgumentos("Estado a renomear: ", "Novo nome: "); ok {
+				executarComandoUsuario(sessao, Comando{"rename", args}, fmt.Sprintf("Estado '%s' renomeado para '%s'.", args[0], args[1]))
+			}
+		case 4:
+			leituraTransicoes(sessao)
+		case 5:
+			if args, ok := leituraArgumentos("Origem: ", "Símbolo (ou \"ε\", \"eps\" ou \"epsilon\" para épsilon): ", "Destino: "); ok {
+				executarComandoUsuario(sessao, Comando{"deltrans", args}, "Transição removida.")
+			}
+		case 6:
+			if leituraEstadoInicial(sessao) {
+				fmt.Printf("Estado inicial agora é '%s'.\n", AFUsuario.EstadoInicial)
+			}
+		case 7:
+			if args, ok := leituraArgumentos("Estado: "); ok {
+				if slices.Contains(AFUsuario.EstadosFinais, args[0]) {
+					executarComandoUsuario(sessao, Comando{"nonfinal", args}, fmt.Sprintf("Estado '%s' deixou de ser final.", args[0]))
+				} else {
+					executarComandoUsuario(sessao, Comando{"final", args}, fmt.Sprintf("Estado '%s' agora é final.", args[0]))
+				}
+			}
+		case 8:
+			exibicaoAutomato(AFUsuario)
+		case 9:
+			if AFUsuario.EstadoInicial == "" {
+				fmt.Println("Erro: defina o estado inicial antes de testar cadeias.")
+				break
+			}
+			testeCadeiasUsuario(AFUsuario)
+		case 10:
+			if comando, err := sessao.desfazer(); err != nil {
+				fmt.Printf("Erro: %v.\n", err)
+			} else {
+				fmt.Printf("Desfeito: %s\n", comando)
+			}
+		case 11:
+			if comando, err := sessao.refazer(); err != nil {
+				fmt.Printf("Erro: %v.\n", err)
+			} else {
+				fmt.Printf("Refeito: %s\n", comando)
+			}
+		case 12:
+			fmt.Print(sessao.script())
+		case 13:
+			if args, ok := leituraArgumentos("Arquivo: "); ok {
+				if err := os.WriteFile(args[0], []byte(sessao.script()), 0o644); err != nil {
+					fmt.Printf("Erro: %v.\n", err)
+				} else {
+					fmt.Printf("Histórico salvo em %s.\n", args[0])
+				}
+			}
+		case 0:
+			return
+		default:
+			fmt.Println("Opção inválida, tente novamente.")
+		}
+	}
+}
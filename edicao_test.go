@@ -0,0 +1,113 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func automatoEdicao() AutomatoFinito {
+	return AutomatoFinito{
+		Estados:  []string{"q0", "q1", "q2"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"q0": {'a': {"q0", "q1"}},
+			"q1": {'b': {"q2"}},
+			"q2": {'a': {"q1"}},
+		},
+		TransicoesEpsilon: map[string][]string{"q0": {"q2"}},
+		EstadoInicial:     "q0",
+		EstadosFinais:     []string{"q1", "q2"},
+	}
+}
+
+func TestRemoverEstado(t *testing.T) {
+	AF := automatoEdicao()
+	if err := AF.removerEstado("q1"); err != nil {
+		t.Fatalf("removerEstado() erro inesperado: %v", err)
+	}
+	expected := AutomatoFinito{
+		Estados:           []string{"q0", "q2"},
+		Alfabeto:          []rune{'a', 'b'},
+		Transicoes:        map[string]map[rune][]string{"q0": {'a': {"q0"}}},
+		TransicoesEpsilon: map[string][]string{"q0": {"q2"}},
+		EstadoInicial:     "q0",
+		EstadosFinais:     []string{"q2"},
+	}
+	if !reflect.DeepEqual(AF, expected) {
+		t.Errorf("removerEstado(q1) = %+v, want %+v", AF, expected)
+	}
+
+	if err := AF.removerEstado("q0"); err != nil || AF.EstadoInicial != "" {
+		t.Errorf("removerEstado(q0): erro %v, EstadoInicial %q, want sem estado inicial", err, AF.EstadoInicial)
+	}
+	if err := AF.removerEstado("q9"); err == nil {
+		t.Errorf("removerEstado(q9) deveria falhar")
+	}
+}
+
+func TestRenomearEstado(t *testing.T) {
+	AF := automatoEdicao()
+	if err := AF.renomearEstado("q0", "inicio"); err != nil {
+		t.Fatalf("renomearEstado() erro inesperado: %v", err)
+	}
+	if err := AF.renomearEstado("q2", "fim"); err != nil {
+		t.Fatalf("renomearEstado() erro inesperado: %v", err)
+	}
+	expected := AutomatoFinito{
+		Estados:  []string{"inicio", "q1", "fim"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"inicio": {'a': {"inicio", "q1"}},
+			"q1":     {'b': {"fim"}},
+			"fim":    {'a': {"q1"}},
+		},
+		TransicoesEpsilon: map[string][]string{"inicio": {"fim"}},
+		EstadoInicial:     "inicio",
+		EstadosFinais:     []string{"q1", "fim"},
+	}
+	if !reflect.DeepEqual(AF, expected) {
+		t.Errorf("renomearEstado() = %+v, want %+v", AF, expected)
+	}
+
+	for _, tt := range [][2]string{{"q9", "x"}, {"q1", "fim"}, {"q1", ""}} {
+		if err := AF.renomearEstado(tt[0], tt[1]); err == nil {
+			t.Errorf("renomearEstado(%q, %q) deveria falhar", tt[0], tt[1])
+		}
+	}
+}
+
+func TestRemoverTransicaoAlternarFinal(t *testing.T) {
+	AF := automatoEdicao()
+	if err := AF.removerTransicao("q0", 'a', "q1"); err != nil {
+		t.Fatalf("removerTransicao() erro inesperado: %v", err)
+	}
+	if err := AF.removerTransicao("q1", 'b', "q2"); err != nil {
+		t.Fatalf("removerTransicao() erro inesperado: %v", err)
+	}
+	expected := map[string]map[rune][]string{
+		"q0": {'a': {"q0"}},
+		"q2": {'a': {"q1"}},
+	}
+	if !reflect.DeepEqual(AF.Transicoes, expected) {
+		t.Errorf("Transicoes = %v, want %v", AF.Transicoes, expected)
+	}
+	if err := AF.removerTransicao("q0", 'b', "q0"); err == nil {
+		t.Errorf("removerTransicao() de transição inexistente deveria falhar")
+	}
+	if err := AF.removerTransicaoEpsilon("q0", "q2"); err != nil || len(AF.TransicoesEpsilon) != 0 {
+		t.Errorf("removerTransicaoEpsilon() erro %v, TransicoesEpsilon = %v", err, AF.TransicoesEpsilon)
+	}
+	if err := AF.removerTransicaoEpsilon("q0", "q2"); err == nil {
+		t.Errorf("removerTransicaoEpsilon() de transição inexistente deveria falhar")
+	}
+
+	if final, err := AF.alternarEstadoFinal("q1"); err != nil || final {
+		t.Errorf("alternarEstadoFinal(q1) = %v, %v, want false, nil", final, err)
+	}
+	if final, err := AF.alternarEstadoFinal("q0"); err != nil || !final {
+		t.Errorf("alternarEstadoFinal(q0) = %v, %v, want true, nil", final, err)
+	}
+	if expected := []string{"q2", "q0"}; !reflect.DeepEqual(AF.EstadosFinais, expected) {
+		t.Errorf("EstadosFinais = %q, want %q", AF.EstadosFinais, expected)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"slices"
+)
+
+// eliminarEpsilon retorna um AFN equivalente sem transições épsilon, com os mesmos estados.
+// Para cada estado q e símbolo a, o novo destino é δ(fecho-ε(q), a); um estado passa a ser
+// final quando algum estado do seu fecho-ε é final.
+func (AF *Automato[S]) eliminarEpsilon() Automato[S] {
+	resultado := Automato[S]{
+		Estados:       slices.Clone(AF.Estados),
+		Alfabeto:      slices.Clone(AF.Alfabeto),
+		Transicoes:    make(map[string]map[S][]string),
+		EstadoInicial: AF.EstadoInicial,
+	}
+
+	for _, estado := range AF.Estados {
+		fecho := AF.epsilonClosure([]string{estado})
+		ordenarEstados(fecho) // Ordem estável dos destinos gerados
+
+		destinosPorSimbolo := make(map[S]map[string]bool)
+		for _, alcancado := range fecho {
+			for simbolo, destinos := range AF.Transicoes[alcancado] {
+				if destinosPorSimbolo[simbolo] == nil {
+					destinosPorSimbolo[simbolo] = make(map[string]bool)
+				}
+				for _, destino := range destinos {
+					destinosPorSimbolo[simbolo][destino] = true
+				}
+			}
+		}
+
+		for simbolo, conjunto := range destinosPorSimbolo {
+			destinos := make([]string, 0, len(conjunto))
+			for destino := range conjunto {
+				destinos = append(destinos, destino)
+			}
+			ordenarEstados(destinos)
+			for _, destino := range destinos {
+				resultado.adicionarTransicao(estado, simbolo, destino)
+			}
+		}
+
+		// Um estado que alcança um final apenas por épsilon também passa a ser final
+		for _, alcancado := range fecho {
+			if slices.Contains(AF.EstadosFinais, alcancado) {
+				resultado.adicionarEstadoFinal(estado)
+				break
+			}
+		}
+	}
+
+	return resultado
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestEliminarEpsilon(t *testing.T) {
+	// a*b com épsilon: q_start --ε--> q_a_loop --ε--> q_b_trans --b--> q_final
+	nfa := AutomatoFinito{
+		Estados:  []string{"q_start", "q_a_loop", "q_b_trans", "q_final"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"q_a_loop":  {'a': {"q_a_loop"}},
+			"q_b_trans": {'b': {"q_final"}},
+		},
+		TransicoesEpsilon: map[string][]string{
+			"q_start":  {"q_a_loop"},
+			"q_a_loop": {"q_b_trans"},
+		},
+		EstadoInicial: "q_start",
+		EstadosFinais: []string{"q_final"},
+	}
+
+	// (a|ε)b? : o estado inicial alcança um final apenas por épsilon
+	nfaFinalPorEpsilon := AutomatoFinito{
+		Estados:  []string{"p0", "p1", "p2"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"p0": {'a': {"p1"}},
+			"p1": {'b': {"p2"}},
+		},
+		TransicoesEpsilon: map[string][]string{"p0": {"p1"}},
+		EstadoInicial:     "p0",
+		EstadosFinais:     []string{"p1", "p2"},
+	}
+
+	tests := []struct {
+		name           string
+		af             *AutomatoFinito
+		expectedFinais []string
+		cadeias        []string
+	}{
+		{"a*b", &nfa, []string{"q_final"}, []string{"", "a", "b", "ab", "aab", "ba", "abb", "acb"}},
+		{"final por épsilon", &nfaFinalPorEpsilon, []string{"p0", "p1", "p2"}, []string{"", "a", "b", "ab", "aa", "bb", "abb"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			semEpsilon := tt.af.eliminarEpsilon()
+
+			if len(semEpsilon.Estados) != len(tt.af.Estados) {
+				t.Errorf("número de estados mudou: got %d, want %d", len(semEpsilon.Estados), len(tt.af.Estados))
+			}
+			for estado, destinos := range semEpsilon.TransicoesEpsilon {
+				if len(destinos) > 0 {
+					t.Errorf("estado %s ainda possui transição épsilon", estado)
+				}
+			}
+			if !slicesEqualIgnoringOrderAndDuplicates(semEpsilon.EstadosFinais, tt.expectedFinais) {
+				t.Errorf("EstadosFinais = %v, want %v", semEpsilon.EstadosFinais, tt.expectedFinais)
+			}
+
+			for _, cadeia := range tt.cadeias {
+				tt.af.adicionarCadeia(cadeia)
+				semEpsilon.adicionarCadeia(cadeia)
+				if got, want := semEpsilon.funcionamento(), tt.af.funcionamento(); got != want {
+					t.Errorf("cadeia \"%s\": sem épsilon = %v, original = %v", cadeia, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// entradaPadrao é lida linha a linha por todos os prompts do menu, para que espaços e a cadeia
+// vazia cheguem inteiros ao programa (fmt.Scan separa por espaços e ignora linhas vazias).
+var entradaPadrao = bufio.NewReader(os.Stdin)
+
+// lerLinha lê a próxima linha sem o terminador. Retorna false no fim da entrada.
+func lerLinha() (string, bool) {
+	linha, err := entradaPadrao.ReadString('\n')
+	if err == io.EOF && linha == "" {
+		return "", false
+	}
+	return strings.TrimRight(linha, "\r\n"), true
+}
+
+// lerEntrada lê um valor já decodificado, ignorando linhas em branco e repetindo a leitura após um
+// escape inválido. Retorna false no fim da entrada ou quando a linha é palavraFim (se não vazia);
+// "\\fim" continua disponível como o valor "fim".
+func lerEntrada(palavraFim string) (string, bool) {
+	for {
+		linha, ok := lerLinha()
+		if !ok {
+			return "", false
+		}
+		linha = strings.TrimSpace(linha)
+		if linha == "" {
+			continue
+		}
+		if palavraFim != "" && linha == palavraFim {
+			return "", false
+		}
+		valor, err := decodificarCadeia(linha)
+		if err != nil {
+			fmt.Printf("Erro: %v. Tente novamente.\n> ", err)
+			continue
+		}
+		return valor, true
+	}
+}
+
+// lerOpcao lê o número escolhido num menu. Retorna false no fim da entrada; uma linha que não é um
+// número vira -1, que nenhum menu usa.
+func lerOpcao() (int, bool) {
+	linha, ok := lerLinha()
+	if !ok {
+		return 0, false
+	}
+	escolha, err := strconv.Atoi(strings.TrimSpace(linha))
+	if err != nil {
+		return -1, true
+	}
+	return escolha, true
+}
+
+// decodificarCadeia converte a forma escrita de uma cadeia: "ε" (ou nada) é a cadeia vazia e a barra
+// invertida escapa o caractere seguinte. \s é um espaço, \t uma tabulação e \uXXXX o caractere
+// Unicode de código XXXX; qualquer outro caractere após a barra vale por si mesmo, de modo que
+// "\ε" é o símbolo ε, "\\" a barra e "\fim" a cadeia "fim" onde "fim" seria um comando.
+func decodificarCadeia(texto string) (string, error) {
+	if texto == string(epsilonRune) {
+		return "", nil
+	}
+	var sb strings.Builder
+	entrada := []rune(texto)
+	for i := 0; i < len(entrada); i++ {
+		if entrada[i] != '\\' {
+			sb.WriteRune(entrada[i])
+			continue
+		}
+		i++
+		if i == len(entrada) {
+			return "", fmt.Errorf("barra invertida sem caractere em %q", texto)
+		}
+		switch entrada[i] {
+		case 's':
+			sb.WriteRune(' ')
+		case 't':
+			sb.WriteRune('\t')
+		case 'u':
+			if i+4 >= len(entrada) {
+				return "", fmt.Errorf("\\u precisa de quatro dígitos hexadecimais em %q", texto)
+			}
+			codigo, err := strconv.ParseUint(string(entrada[i+1:i+5]), 16, 32)
+			if err != nil {
+				return "", fmt.Errorf("\\u precisa de quatro dígitos hexadecimais em %q", texto)
+			}
+			sb.WriteRune(rune(codigo))
+			i += 4
+		default:
+			sb.WriteRune(entrada[i])
+		}
+	}
+	return sb.String(), nil
+}
+
+// codificarCadeia é o inverso de decodificarCadeia para um argumento de comando: o resultado não tem
+// espaços e é decodificado de volta para a mesma cadeia.
+func codificarCadeia(cadeia string) string {
+	if cadeia == "" {
+		return string(epsilonRune)
+	}
+	var sb strings.Builder
+	for _, r := range cadeia {
+		switch r {
+		case ' ':
+			sb.WriteString(`\s`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\\', epsilonRune:
+			sb.WriteRune('\\')
+			sb.WriteRune(r)
+		default:
+			if unicode.IsSpace(r) {
+				fmt.Fprintf(&sb, `\u%04x`, r)
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bufio"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// simularEntrada faz os prompts lerem de texto durante o teste.
+func simularEntrada(t *testing.T, texto string) {
+	anterior := entradaPadrao
+	entradaPadrao = bufio.NewReader(strings.NewReader(texto))
+	t.Cleanup(func() { entradaPadrao = anterior })
+}
+
+func TestDecodificarCadeia(t *testing.T) {
+	tests := []struct {
+		texto    string
+		expected string
+	}{
+		{"", ""},
+		{"ε", ""},
+		{"\\ε", "ε"},
+		{"aεb", "aεb"},
+		{"a b", "a b"},
+		{"a\\sb", "a b"},
+		{"\\ ", " "},
+		{"a\\tb", "a\tb"},
+		{"\\\\", "\\"},
+		{"\\fim", "fim"},
+		{"\\u00e9x", "éx"},
+	}
+	for _, tt := range tests {
+		got, err := decodificarCadeia(tt.texto)
+		if err != nil || got != tt.expected {
+			t.Errorf("decodificarCadeia(%q) = %q, %v, want %q", tt.texto, got, err, tt.expected)
+		}
+	}
+	for _, texto := range []string{"a\\", "\\u12", "\\uzzzz"} {
+		if _, err := decodificarCadeia(texto); err == nil {
+			t.Errorf("decodificarCadeia(%q) deveria falhar", texto)
+		}
+	}
+}
+
+func TestLeituraComLinhasInteiras(t *testing.T) {
+	simularEntrada(t, "q0\n\n  q 1  \n\\fim\nε\nfim\nq 1\na\n\\s\n\\ε\nfim\n")
+	sessao := novaSessao(AutomatoFinito{})
+	AF := &sessao.Automato
+	leituraEstados(sessao)
+	if expected := []string{"q0", "q 1", "fim"}; !slices.Equal(AF.Estados, expected) {
+		t.Errorf("Estados = %q, want %q", AF.Estados, expected)
+	}
+	if !leituraEstadoInicial(sessao) || AF.EstadoInicial != "q 1" {
+		t.Errorf("EstadoInicial = %q, want \"q 1\"", AF.EstadoInicial)
+	}
+	leituraAlfabeto(sessao)
+	if expected := []rune{'a', ' ', 'ε'}; !slices.Equal(AF.Alfabeto, expected) {
+		t.Errorf("Alfabeto = %q, want %q", AF.Alfabeto, expected)
+	}
+}
+
+func TestLeituraFimDaEntrada(t *testing.T) {
+	simularEntrada(t, "q0\nq1")
+	sessao := novaSessao(AutomatoFinito{})
+	AF := &sessao.Automato
+	leituraEstados(sessao) // termina no fim da entrada, sem "fim"
+	if expected := []string{"q0", "q1"}; !slices.Equal(AF.Estados, expected) {
+		t.Errorf("Estados = %q, want %q", AF.Estados, expected)
+	}
+	if leituraEstadoInicial(sessao) {
+		t.Errorf("leituraEstadoInicial() sem entrada deveria retornar false")
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// exemploModelo é um modelo pronto do menu "Outros Modelos": a descrição é exibida ao escolhê-lo e
+// cada cadeia digitada é passada a testar.
+type exemploModelo struct {
+	titulo    string
+	descricao string
+	preparar  func() func(cadeia string)
+}
+
+var exemplosModelos = []exemploModelo{
+	{"Autômato com pilha: aⁿbⁿ", "Aceita aⁿbⁿ (n ≥ 0) por estado final e mostra a sequência de configurações.", exemploPilha},
+	{"Máquina de Turing: incremento binário", "Soma 1 ao número binário da fita, mostrando a fita a cada passo.", exemploTuring},
+	{"Autômato ponderado (tropical): custo mínimo", "Lê (a|b)*b: 'a' custa 1 e 'b' custa 3, ou 1 quando é o último símbolo.", exemploPonderado},
+	{"Autômato probabilístico: aⁿb", "Em cada passo lê 'a' ou 'b' com probabilidade 1/2 e para depois do 'b'.", exemploProbabilistico},
+	{"Máquinas de Moore e Mealy: paridade", "A máquina de Moore emite P ou I conforme a paridade de '1's lidos; a Mealy é a sua conversão.", exemploTransdutores},
+}
+
+// menuModelos oferece, por meio de exemplos prontos, os modelos que não são AutomatoFinito.
+func menuModelos() {
+	for {
+		fmt.Println("\n--- Outros Modelos ---")
+		for i, exemplo := range exemplosModelos {
+			fmt.Printf("%d. %s\n", i+1, exemplo.titulo)
+		}
+		fmt.Println("0. Voltar ao menu principal")
+		fmt.Print("Escolha uma opção: ")
+
+		escolha, ok := lerOpcao()
+		if !ok || escolha == 0 {
+			return
+		}
+		if escolha < 1 || escolha > len(exemplosModelos) {
+			fmt.Println("Opção inválida, tente novamente.")
+			continue
+		}
+		exemplo := exemplosModelos[escolha-1]
+		fmt.Println(exemplo.descricao)
+		if !testarCadeiasModelo(exemplo.preparar()) {
+			return
+		}
+	}
+}
+
+// testarCadeiasModelo lê cadeias até "sair", como testeCadeiasUsuario. Retorna false no fim da entrada.
+func testarCadeiasModelo(testar func(cadeia string)) bool {
+	fmt.Println("Digite a cadeia para testar (ou \"sair\" para voltar).")
+	for {
+		fmt.Print("> ")
+		linha, ok := lerLinha()
+		if !ok {
+			return false
+		}
+		if linha == "sair" {
+			return true
+		}
+		cadeia, err := decodificarCadeia(linha)
+		if err != nil {
+			fmt.Printf("Erro: %v.\n", err)
+			continue
+		}
+		testar(cadeia)
+	}
+}
+
+func exemploPilha() func(string) {
+	AP := AutomatoPilha{}
+	AP.adicionarEstado("q0")
+	AP.adicionarEstado("q1")
+	AP.adicionarEstado("q2")
+	AP.adicionarTransicao("q0", 'a', "", "A", "q0")
+	AP.adicionarTransicaoEpsilon("q0", "", "", "q1")
+	AP.adicionarTransicao("q1", 'b', "A", "", "q1")
+	AP.adicionarTransicaoEpsilon("q1", "Z", "Z", "q2")
+	AP.adicionarEstadoInicial("q0", "Z")
+	AP.adicionarEstadoFinal("q2")
+	return func(cadeia string) {
+		AP.adicionarCadeia(cadeia)
+		exibicaoTracePilha(AP.funcionamento())
+	}
+}
+
+func exemploTuring() func(string) {
+	MT := MaquinaTuring{Branco: '_', EstadoInicial: "direita", EstadoAceita: "fim", EstadoRejeita: "erro", LimitePassos: 1000}
+	for _, estado := range []string{"direita", "soma", "fim", "erro"} {
+		MT.adicionarEstado(estado)
+	}
+	MT.adicionarTransicao("direita", '0', "direita", '0', MovimentoDireita)
+	MT.adicionarTransicao("direita", '1', "direita", '1', MovimentoDireita)
+	MT.adicionarTransicao("direita", '_', "soma", '_', MovimentoEsquerda)
+	MT.adicionarTransicao("soma", '1', "soma", '0', MovimentoEsquerda)
+	MT.adicionarTransicao("soma", '0', "fim", '1', MovimentoParado)
+	MT.adicionarTransicao("soma", '_', "fim", '1', MovimentoParado)
+	return func(cadeia string) {
+		if strings.Trim(cadeia, "01") != "" {
+			fmt.Println("Use apenas os dígitos 0 e 1.")
+			return
+		}
+		execucao := MT.executar(cadeia, os.Stdout)
+		fmt.Printf("Resultado: %s em %d passo(s); fita final: %s\n", execucao.Resultado, execucao.Passos, execucao.Fita)
+	}
+}
+
+func exemploPonderado() func(string) {
+	AP := AutomatoPonderado[float64]{Semianel: SemianelTropical{}}
+	AP.adicionarEstado("q0")
+	AP.adicionarEstado("q1")
+	AP.adicionarTransicao("q0", 'a', "q0", 1)
+	AP.adicionarTransicao("q0", 'b', "q0", 3)
+	AP.adicionarTransicao("q0", 'b', "q1", 1)
+	AP.adicionarEstadoInicial("q0")
+	AP.adicionarEstadoFinal("q1", 0)
+	if cadeia, custo, ok, err := AP.melhorCaminho(); err == nil && ok {
+		fmt.Printf("Cadeia aceita de menor custo: %s (custo %g)\n", formatarCadeia(cadeia), custo)
+	}
+	return func(cadeia string) {
+		if custo := AP.peso(cadeia); custo == AP.Semianel.Zero() {
+			fmt.Println("Cadeia não aceita (custo infinito)")
+		} else {
+			fmt.Printf("Custo mínimo: %g\n", custo)
+		}
+	}
+}
+
+func exemploProbabilistico() func(string) {
+	AP := AutomatoProbabilistico{}
+	AP.adicionarEstado("q0")
+	AP.adicionarEstado("q1")
+	AP.adicionarEstadoInicial("q0")
+	AP.adicionarTransicaoProbabilidade("q0", 'a', "q0", 0.5)
+	AP.adicionarTransicaoProbabilidade("q0", 'b', "q1", 0.5)
+	AP.adicionarParada("q1", 1)
+	if amostras, err := AP.amostrarCadeias(5, 1); err == nil {
+		fmt.Println("Cinco cadeias sorteadas (semente 1):")
+		for _, amostra := range amostras {
+			fmt.Printf("  %s\n", formatarCadeia(amostra))
+		}
+	}
+	return func(cadeia string) {
+		fmt.Printf("Probabilidade: %g\n", AP.probabilidade(cadeia))
+	}
+}
+
+func exemploTransdutores() func(string) {
+	moore := MaquinaMoore{}
+	moore.adicionarEstado("par")
+	moore.adicionarEstado("impar")
+	moore.adicionarAlfabeto('0')
+	moore.adicionarAlfabeto('1')
+	moore.adicionarTransicao("par", '0', "par")
+	moore.adicionarTransicao("par", '1', "impar")
+	moore.adicionarTransicao("impar", '0', "impar")
+	moore.adicionarTransicao("impar", '1', "par")
+	moore.adicionarEstadoInicial("par")
+	moore.adicionarSaida("par", "P")
+	moore.adicionarSaida("impar", "I")
+	mealy := moore.paraMealy()
+	return func(cadeia string) {
+		saidaMoore, err := moore.executar(cadeia)
+		if err != nil {
+			fmt.Printf("Erro: %v.\n", err)
+			return
+		}
+		saidaMealy, _ := mealy.executar(cadeia)
+		fmt.Printf("Moore: %s\nMealy: %s\n", strings.Join(saidaMoore, " "), strings.Join(saidaMealy, " "))
+	}
+}
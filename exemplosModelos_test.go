@@ -0,0 +1,14 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestMenuModelos(t *testing.T) {
+	// Cada exemplo recebe uma cadeia e "sair"; a opção inválida e o 0 encerram o menu
+	simularEntrada(t, "1\naabb\nsair\n2\n1011\n1x\nsair\n3\nab\nsair\n4\naab\nsair\n5\n0110\nsair\n9\n0\n")
+	menuModelos()
+	if linha, ok := lerLinha(); ok {
+		t.Errorf("menuModelos() deixou a entrada %q sem ler", linha)
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// ConfiguracaoGerador descreve o autômato aleatório a ser gerado por gerarAutomato.
+type ConfiguracaoGerador struct {
+	NumEstados           int
+	Alfabeto             []rune
+	Densidade            float64 // probabilidade de cada transição possível existir
+	ProbabilidadeEpsilon float64 // probabilidade de cada par de estados distintos ter uma transição épsilon
+	NumFinais            int
+	Deterministico       bool
+	Alcancavel           bool // todo estado é alcançável a partir do inicial
+	Aparado              bool // além de alcançável, todo estado alcança algum final
+	Semente              int64
+}
+
+// coAlcancaveis retorna os estados a partir dos quais algum estado final é alcançável.
+func (AF *Automato[S]) coAlcancaveis() map[string]bool {
+	antecessores := make(map[string][]string)
+	for origem, m := range AF.Transicoes {
+		for _, destinos := range m {
+			for _, destino := range destinos {
+				antecessores[destino] = append(antecessores[destino], origem)
+			}
+		}
+	}
+	for origem, destinos := range AF.TransicoesEpsilon {
+		for _, destino := range destinos {
+			antecessores[destino] = append(antecessores[destino], origem)
+		}
+	}
+	co := make(map[string]bool)
+	pilha := slices.Clone(AF.EstadosFinais)
+	for _, final := range pilha {
+		co[final] = true
+	}
+	for len(pilha) > 0 {
+		estado := pilha[len(pilha)-1]
+		pilha = pilha[:len(pilha)-1]
+		for _, antecessor := range antecessores[estado] {
+			if !co[antecessor] {
+				co[antecessor] = true
+				pilha = append(pilha, antecessor)
+			}
+		}
+	}
+	return co
+}
+
+// gerarAutomato gera um autômato aleatório com estados q0, q1, ... (q0 inicial). A mesma
+// configuração, incluindo a semente, gera sempre o mesmo autômato.
+//
+// Para garantir o alcance, cada estado qi (i > 0) recebe primeiro uma transição de algum qj com
+// j < i, formando uma árvore a partir de q0. Para aparar, os estados são visitados do último para
+// o primeiro e cada um que não alcança um final ganha uma transição para um estado que alcança;
+// como os filhos na árvore já foram tratados, num AFD sem símbolo livre sempre há uma transição
+// fora da árvore que pode ser redirecionada.
+func gerarAutomato(cfg ConfiguracaoGerador) (AutomatoFinito, error) {
+	switch {
+	case cfg.NumEstados < 1:
+		return AutomatoFinito{}, errors.New("o autômato precisa de pelo menos um estado")
+	case cfg.NumFinais < 0 || cfg.NumFinais > cfg.NumEstados:
+		return AutomatoFinito{}, fmt.Errorf("número de finais deve estar entre 0 e %d", cfg.NumEstados)
+	case cfg.Densidade < 0 || cfg.Densidade > 1 || cfg.ProbabilidadeEpsilon < 0 || cfg.ProbabilidadeEpsilon > 1:
+		return AutomatoFinito{}, errors.New("densidade e probabilidade de épsilon devem estar entre 0 e 1")
+	case cfg.Deterministico && cfg.ProbabilidadeEpsilon > 0:
+		return AutomatoFinito{}, errors.New("um AFD não pode ter transições épsilon")
+	case (cfg.Alcancavel || cfg.Aparado) && cfg.NumEstados > 1 && len(cfg.Alfabeto) == 0:
+		return AutomatoFinito{}, errors.New("é preciso ao menos um símbolo para tornar os estados alcançáveis")
+	case cfg.Aparado && cfg.NumFinais == 0:
+		return AutomatoFinito{}, errors.New("um autômato aparado precisa de ao menos um estado final")
+	}
+
+	gerador := rand.New(rand.NewSource(cfg.Semente))
+	AF := AutomatoFinito{Alfabeto: slices.Clone(cfg.Alfabeto)}
+	estados := make([]string, cfg.NumEstados)
+	for i := range estados {
+		estados[i] = fmt.Sprintf("q%d", i)
+		AF.adicionarEstado(estados[i])
+	}
+	AF.adicionarEstadoInicial(estados[0])
+
+	simbolosLivres := func(estado string) []rune {
+		var livres []rune
+		for _, simbolo := range cfg.Alfabeto {
+			if len(AF.Transicoes[estado][simbolo]) == 0 {
+				livres = append(livres, simbolo)
+			}
+		}
+		return livres
+	}
+	type aresta struct {
+		origem  string
+		simbolo rune
+	}
+	arvore := make(map[aresta]bool)
+
+	if cfg.Alcancavel || cfg.Aparado {
+		for i := 1; i < cfg.NumEstados; i++ {
+			var candidatos []string
+			for _, estado := range estados[:i] {
+				if !cfg.Deterministico || len(simbolosLivres(estado)) > 0 {
+					candidatos = append(candidatos, estado)
+				}
+			}
+			pai := candidatos[gerador.Intn(len(candidatos))]
+			opcoes := cfg.Alfabeto
+			if cfg.Deterministico {
+				opcoes = simbolosLivres(pai)
+			}
+			simbolo := opcoes[gerador.Intn(len(opcoes))]
+			AF.adicionarTransicao(pai, simbolo, estados[i])
+			arvore[aresta{pai, simbolo}] = true
+		}
+	}
+
+	for _, origem := range estados {
+		for _, simbolo := range cfg.Alfabeto {
+			if cfg.Deterministico {
+				if len(AF.Transicoes[origem][simbolo]) == 0 && gerador.Float64() < cfg.Densidade {
+					AF.adicionarTransicao(origem, simbolo, estados[gerador.Intn(len(estados))])
+				}
+				continue
+			}
+			for _, destino := range estados {
+				if gerador.Float64() < cfg.Densidade && !slices.Contains(AF.Transicoes[origem][simbolo], destino) {
+					AF.adicionarTransicao(origem, simbolo, destino)
+				}
+			}
+		}
+		for _, destino := range estados {
+			if destino != origem && gerador.Float64() < cfg.ProbabilidadeEpsilon {
+				AF.adicionarTransicaoEpsilon(origem, destino)
+			}
+		}
+	}
+
+	for _, i := range gerador.Perm(cfg.NumEstados)[:cfg.NumFinais] {
+		AF.adicionarEstadoFinal(estados[i])
+	}
+	slices.SortFunc(AF.EstadosFinais, func(a, b string) int {
+		return slices.Index(estados, a) - slices.Index(estados, b)
+	})
+
+	if cfg.Aparado {
+		for i := cfg.NumEstados - 1; i >= 0; i-- {
+			co := AF.coAlcancaveis()
+			if co[estados[i]] {
+				continue
+			}
+			var alvos []string
+			for _, estado := range estados {
+				if co[estado] {
+					alvos = append(alvos, estado)
+				}
+			}
+			alvo := alvos[gerador.Intn(len(alvos))]
+			origem := estados[i]
+
+			if !cfg.Deterministico {
+				AF.adicionarTransicao(origem, cfg.Alfabeto[gerador.Intn(len(cfg.Alfabeto))], alvo)
+				continue
+			}
+			if livres := simbolosLivres(origem); len(livres) > 0 {
+				AF.adicionarTransicao(origem, livres[gerador.Intn(len(livres))], alvo)
+				continue
+			}
+			var redirecionaveis []rune
+			for _, simbolo := range cfg.Alfabeto {
+				if !arvore[aresta{origem, simbolo}] {
+					redirecionaveis = append(redirecionaveis, simbolo)
+				}
+			}
+			AF.Transicoes[origem][redirecionaveis[gerador.Intn(len(redirecionaveis))]] = []string{alvo}
+		}
+	}
+	return AF, nil
+}
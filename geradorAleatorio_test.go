@@ -0,0 +1,73 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGerarAutomato(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  ConfiguracaoGerador
+	}{
+		{"AFD aparado esparso", ConfiguracaoGerador{NumEstados: 30, Alfabeto: []rune{'a', 'b'}, Densidade: 0.1, NumFinais: 1, Deterministico: true, Aparado: true, Semente: 1}},
+		{"AFD aparado denso", ConfiguracaoGerador{NumEstados: 20, Alfabeto: []rune{'a', 'b', 'c'}, Densidade: 1, NumFinais: 2, Deterministico: true, Aparado: true, Semente: 2}},
+		{"AFN alcançável com épsilon", ConfiguracaoGerador{NumEstados: 15, Alfabeto: []rune{'0', '1'}, Densidade: 0.05, ProbabilidadeEpsilon: 0.05, NumFinais: 3, Alcancavel: true, Semente: 3}},
+		{"AFN aparado", ConfiguracaoGerador{NumEstados: 25, Alfabeto: []rune{'x'}, Densidade: 0.02, NumFinais: 1, Aparado: true, Semente: 4}},
+		{"um estado", ConfiguracaoGerador{NumEstados: 1, NumFinais: 1, Aparado: true, Semente: 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			AF, err := gerarAutomato(tt.cfg)
+			if err != nil {
+				t.Fatalf("gerarAutomato() erro inesperado: %v", err)
+			}
+			if len(AF.Estados) != tt.cfg.NumEstados || len(AF.EstadosFinais) != tt.cfg.NumFinais {
+				t.Errorf("gerou %d estados e %d finais, want %d e %d", len(AF.Estados), len(AF.EstadosFinais), tt.cfg.NumEstados, tt.cfg.NumFinais)
+			}
+			if tt.cfg.Deterministico && !AF.ehDeterministico() {
+				t.Errorf("autômato gerado não é determinístico")
+			}
+
+			if tt.cfg.Alcancavel || tt.cfg.Aparado {
+				alcancaveis := AF.epsilonClosure([]string{AF.EstadoInicial})
+				alcancaveis = append(alcancaveis, AF.ordemBFS(AF.simbolosOrdenados())...)
+				if !slicesEqualIgnoringOrderAndDuplicates(alcancaveis, AF.Estados) {
+					t.Errorf("estados inalcançáveis: alcançados %v de %v", alcancaveis, AF.Estados)
+				}
+			}
+			if tt.cfg.Aparado {
+				co := AF.coAlcancaveis()
+				for _, estado := range AF.Estados {
+					if !co[estado] {
+						t.Errorf("estado %s não alcança nenhum final", estado)
+					}
+				}
+			}
+
+			repetido, _ := gerarAutomato(tt.cfg)
+			if !reflect.DeepEqual(AF, repetido) {
+				t.Errorf("a mesma semente gerou autômatos diferentes")
+			}
+		})
+	}
+}
+
+func TestGerarAutomatoConfiguracaoInvalida(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  ConfiguracaoGerador
+	}{
+		{"sem estados", ConfiguracaoGerador{}},
+		{"finais demais", ConfiguracaoGerador{NumEstados: 2, NumFinais: 3}},
+		{"AFD com épsilon", ConfiguracaoGerador{NumEstados: 2, Deterministico: true, ProbabilidadeEpsilon: 0.5}},
+		{"aparado sem finais", ConfiguracaoGerador{NumEstados: 2, Alfabeto: []rune{'a'}, Aparado: true}},
+		{"alcançável sem alfabeto", ConfiguracaoGerador{NumEstados: 2, Alcancavel: true}},
+	}
+	for _, tt := range tests {
+		if _, err := gerarAutomato(tt.cfg); err == nil {
+			t.Errorf("%s: gerarAutomato() deveria retornar erro", tt.name)
+		}
+	}
+}
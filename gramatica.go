@@ -0,0 +1,342 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Producao é uma alternativa de uma gramática linear: uma sequência de terminais seguida (linear à
+// direita) ou precedida (linear à esquerda) de no máximo uma variável.
+type Producao struct {
+	Terminais []rune // vazio representa ε
+	Variavel  string // vazio quando a alternativa só tem terminais
+}
+
+// Gramatica é uma gramática regular na notação "S -> aA | b | ε". Variáveis são uma letra
+// maiúscula seguida opcionalmente de dígitos ou apóstrofos (A, B1, C'); qualquer outro caractere
+// que não seja espaço é terminal. Uma barra invertida faz do caractere seguinte um terminal, com os
+// escapes de decodificarCadeia (\A, \|, \ε, \s). "S -> ∅" declara uma variável sem produções.
+type Gramatica struct {
+	Inicial        string
+	Variaveis      []string // na ordem em que aparecem; a primeira é a inicial
+	Terminais      []rune
+	Producoes      map[string][]Producao
+	LinearEsquerda bool // variável antes dos terminais (A -> Bw) em vez de depois (A -> wB)
+}
+
+// simboloGramatica é um terminal ou uma variável lido do lado direito de uma produção.
+type simboloGramatica struct {
+	terminal rune
+	variavel string
+}
+
+// lerGramatica interpreta uma gramática linear à direita ou à esquerda, uma regra por linha.
+// Linhas vazias e iniciadas por '#' são ignoradas; a mesma variável pode aparecer em várias linhas.
+func lerGramatica(texto string) (Gramatica, error) {
+	G := Gramatica{Producoes: make(map[string][]Producao)}
+	temDireita, temEsquerda := false, false
+
+	for numero, linha := range strings.Split(texto, "\n") {
+		linha = strings.TrimSpace(linha)
+		if linha == "" || strings.HasPrefix(linha, "#") {
+			continue
+		}
+		esquerdo, direito, ok := strings.Cut(linha, "->")
+		if !ok {
+			return Gramatica{}, fmt.Errorf("linha %d: esperado \"->\"", numero+1)
+		}
+
+		cabeca, err := lerLadoDireito(strings.TrimSpace(esquerdo))
+		if err != nil || len(cabeca) != 1 || cabeca[0].variavel == "" {
+			return Gramatica{}, fmt.Errorf("linha %d: o lado esquerdo deve ser uma única variável", numero+1)
+		}
+		variavel := cabeca[0].variavel
+		G.adicionarVariavel(variavel)
+
+		if strings.TrimSpace(direito) == string(conjuntoVazio) {
+			continue
+		}
+		for _, alternativa := range separarAlternativas(direito) {
+			simbolos, err := lerLadoDireito(strings.TrimSpace(alternativa))
+			if err != nil {
+				return Gramatica{}, fmt.Errorf("linha %d: %w", numero+1, err)
+			}
+
+			var producao Producao
+			for i, simbolo := range simbolos {
+				if simbolo.variavel == "" {
+					producao.Terminais = append(producao.Terminais, simbolo.terminal)
+					if !slices.Contains(G.Terminais, simbolo.terminal) {
+						G.Terminais = append(G.Terminais, simbolo.terminal)
+					}
+					continue
+				}
+				if producao.Variavel != "" {
+					return Gramatica{}, fmt.Errorf("linha %d: mais de uma variável em \"%s\"", numero+1, strings.TrimSpace(alternativa))
+				}
+				producao.Variavel = simbolo.variavel
+				G.adicionarVariavel(simbolo.variavel)
+				switch {
+				case len(simbolos) == 1:
+					// Produção unitária, válida nas duas formas
+				case i == len(simbolos)-1:
+					temDireita = true
+				case i == 0:
+					temEsquerda = true
+				default:
+					return Gramatica{}, fmt.Errorf("linha %d: variável no meio de \"%s\"", numero+1, strings.TrimSpace(alternativa))
+				}
+			}
+			G.Producoes[variavel] = append(G.Producoes[variavel], producao)
+		}
+	}
+
+	if len(G.Variaveis) == 0 {
+		return Gramatica{}, fmt.Errorf("gramática vazia")
+	}
+	if temDireita && temEsquerda {
+		return Gramatica{}, fmt.Errorf("a gramática mistura produções lineares à direita e à esquerda")
+	}
+	G.Inicial = G.Variaveis[0]
+	G.LinearEsquerda = temEsquerda
+	return G, nil
+}
+
+// conjuntoVazio, sozinho no lado direito, indica que a variável não tem produções.
+const conjuntoVazio = '∅'
+
+// separarAlternativas divide o lado direito nas barras verticais que não estão escapadas.
+func separarAlternativas(direito string) []string {
+	var alternativas []string
+	inicio := 0
+	for i := 0; i < len(direito); i++ {
+		switch direito[i] {
+		case '\\':
+			i++
+		case '|':
+			alternativas = append(alternativas, direito[inicio:i])
+			inicio = i + 1
+		}
+	}
+	return append(alternativas, direito[inicio:])
+}
+
+// lerLadoDireito separa uma alternativa em terminais e variáveis. "ε" sozinho representa a cadeia vazia.
+func lerLadoDireito(alternativa string) ([]simboloGramatica, error) {
+	if alternativa == string(epsilonRune) {
+		return nil, nil
+	}
+	if alternativa == "" {
+		return nil, fmt.Errorf("alternativa vazia (use ε para a cadeia vazia)")
+	}
+
+	var simbolos []simboloGramatica
+	runas := []rune(alternativa)
+	for i := 0; i < len(runas); i++ {
+		r := runas[i]
+		switch {
+		case unicode.IsSpace(r):
+			continue
+		case r == '\\':
+			fim := min(i+2, len(runas))
+			if fim < len(runas) && runas[i+1] == 'u' {
+				fim = min(i+6, len(runas))
+			}
+			terminal, err := decodificarCadeia(string(runas[i:fim]))
+			if err != nil {
+				return nil, err
+			}
+			simbolos = append(simbolos, simboloGramatica{terminal: []rune(terminal)[0]})
+			i = fim - 1
+		case r == epsilonRune:
+			return nil, fmt.Errorf("ε deve aparecer sozinho em \"%s\" (use \\ε para o terminal ε)", alternativa)
+		case r == conjuntoVazio:
+			return nil, fmt.Errorf("∅ deve aparecer sozinho no lado direito (use \\∅ para o terminal ∅)")
+		case unicode.IsUpper(r):
+			fim := i + 1
+			for fim < len(runas) && (unicode.IsDigit(runas[fim]) || runas[fim] == '\'') {
+				fim++
+			}
+			simbolos = append(simbolos, simboloGramatica{variavel: string(runas[i:fim])})
+			i = fim - 1
+		default:
+			simbolos = append(simbolos, simboloGramatica{terminal: r})
+		}
+	}
+	return simbolos, nil
+}
+
+func (G *Gramatica) adicionarVariavel(variavel string) {
+	if !slices.Contains(G.Variaveis, variavel) {
+		G.Variaveis = append(G.Variaveis, variavel)
+	}
+}
+
+// paraAutomato constrói um AFN equivalente à gramática. Cada variável vira um estado e um novo
+// estado "qf" é o único final; produções com vários terminais ganham estados intermediários.
+// Gramáticas lineares à esquerda são invertidas, convertidas e o autômato resultante é revertido.
+func (G *Gramatica) paraAutomato() AutomatoFinito {
+	AF := AutomatoFinito{Alfabeto: slices.Clone(G.Terminais)}
+	for _, variavel := range G.Variaveis {
+		AF.adicionarEstado(variavel)
+	}
+	final := AF.nomeNovoEstado("qf")
+	AF.adicionarEstado(final)
+	AF.adicionarEstadoInicial(G.Inicial)
+	AF.adicionarEstadoFinal(final)
+
+	intermediarios := 0
+	for _, variavel := range G.Variaveis {
+		for _, producao := range G.Producoes[variavel] {
+			terminais := slices.Clone(producao.Terminais)
+			if G.LinearEsquerda {
+				slices.Reverse(terminais)
+			}
+			destino := producao.Variavel
+			if destino == "" {
+				destino = final
+			}
+
+			atual := variavel
+			for i, terminal := range terminais {
+				proximo := destino
+				if i < len(terminais)-1 {
+					intermediarios++
+					proximo = AF.nomeNovoEstado("q" + strconv.Itoa(intermediarios))
+					AF.adicionarEstado(proximo)
+				}
+				AF.adicionarTransicao(atual, terminal, proximo)
+				atual = proximo
+			}
+			if len(terminais) == 0 {
+				AF.adicionarTransicaoEpsilon(variavel, destino)
+			}
+		}
+	}
+
+	if G.LinearEsquerda {
+		return AF.reverso()
+	}
+	return AF
+}
+
+// paraGramatica retorna uma gramática linear à direita equivalente ao autômato: o estado inicial
+// vira S e os demais A, B, C, ... (A1, B1, ... depois da 25ª variável). Cada transição p --a--> q
+// vira P -> aQ, cada transição épsilon P -> Q e cada estado final P -> ε.
+func paraGramatica(AF *AutomatoFinito) Gramatica {
+	estados := slices.Clone(AF.Estados)
+	for _, origem := range chavesOrdenadas(AF.Transicoes) {
+		for _, simbolo := range slices.Sorted(maps.Keys(AF.Transicoes[origem])) {
+			for _, estado := range append([]string{origem}, AF.Transicoes[origem][simbolo]...) {
+				if !slices.Contains(estados, estado) {
+					estados = append(estados, estado)
+				}
+			}
+		}
+	}
+	for _, origem := range chavesOrdenadas(AF.TransicoesEpsilon) {
+		for _, estado := range append([]string{origem}, AF.TransicoesEpsilon[origem]...) {
+			if !slices.Contains(estados, estado) {
+				estados = append(estados, estado)
+			}
+		}
+	}
+	if i := slices.Index(estados, AF.EstadoInicial); i > 0 {
+		estados = slices.Insert(slices.Delete(estados, i, i+1), 0, AF.EstadoInicial)
+	}
+
+	const letras = "ABCDEFGHIJKLMNOPQRTUVWXYZ" // S fica reservado para o estado inicial
+	variavelDe := make(map[string]string, len(estados))
+	proxima := 0
+	for _, estado := range estados {
+		if estado == AF.EstadoInicial {
+			variavelDe[estado] = "S"
+			continue
+		}
+		nome := string(letras[proxima%len(letras)])
+		if proxima >= len(letras) {
+			nome += strconv.Itoa(proxima / len(letras))
+		}
+		variavelDe[estado] = nome
+		proxima++
+	}
+
+	G := Gramatica{
+		Inicial:   "S",
+		Terminais: AF.simbolosOrdenados(),
+		Producoes: make(map[string][]Producao),
+	}
+	for _, estado := range estados {
+		variavel := variavelDe[estado]
+		G.adicionarVariavel(variavel)
+		for _, destino := range AF.TransicoesEpsilon[estado] {
+			G.Producoes[variavel] = append(G.Producoes[variavel], Producao{Variavel: variavelDe[destino]})
+		}
+		for _, simbolo := range G.Terminais {
+			for _, destino := range AF.Transicoes[estado][simbolo] {
+				G.Producoes[variavel] = append(G.Producoes[variavel], Producao{Terminais: []rune{simbolo}, Variavel: variavelDe[destino]})
+			}
+		}
+		if slices.Contains(AF.EstadosFinais, estado) {
+			G.Producoes[variavel] = append(G.Producoes[variavel], Producao{})
+		}
+	}
+	return G
+}
+
+// String imprime a gramática na mesma notação aceita por lerGramatica. A variável inicial vem
+// sempre primeiro, como "S -> ∅" se não tiver produções, para continuar inicial ao ser relida; as
+// demais variáveis sem produções são omitidas.
+func (G Gramatica) String() string {
+	var sb strings.Builder
+	variaveis := G.Variaveis
+	if G.Inicial != "" {
+		variaveis = append([]string{G.Inicial}, slices.DeleteFunc(slices.Clone(variaveis), func(v string) bool { return v == G.Inicial })...)
+	}
+	for _, variavel := range variaveis {
+		producoes := G.Producoes[variavel]
+		if len(producoes) == 0 {
+			if variavel == G.Inicial {
+				fmt.Fprintf(&sb, "%s -> %c\n", variavel, conjuntoVazio)
+			}
+			continue
+		}
+		alternativas := make([]string, len(producoes))
+		for i, producao := range producoes {
+			switch {
+			case len(producao.Terminais) == 0 && producao.Variavel == "":
+				alternativas[i] = string(epsilonRune)
+			case G.LinearEsquerda:
+				alternativas[i] = producao.Variavel + codificarTerminais(producao.Terminais, producao.Variavel != "")
+			default:
+				alternativas[i] = codificarTerminais(producao.Terminais, false) + producao.Variavel
+			}
+		}
+		fmt.Fprintf(&sb, "%s -> %s\n", variavel, strings.Join(alternativas, " | "))
+	}
+	return sb.String()
+}
+
+// codificarTerminais escapa os terminais que lerLadoDireito leria de outra forma: maiúsculas
+// (variáveis), espaços, |, \, ε e ∅. Logo depois de uma variável, dígitos e apóstrofos também são
+// escapados, para não serem lidos como parte do nome dela.
+func codificarTerminais(terminais []rune, aposVariavel bool) string {
+	var sb strings.Builder
+	for i, r := range terminais {
+		switch {
+		case unicode.IsSpace(r):
+			sb.WriteString(codificarCadeia(string(r)))
+		case unicode.IsUpper(r) || strings.ContainsRune(`|\`, r) || r == epsilonRune || r == conjuntoVazio,
+			i == 0 && aposVariavel && (unicode.IsDigit(r) || r == '\''):
+			sb.WriteRune('\\')
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLerGramatica(t *testing.T) {
+	tests := []struct {
+		name       string
+		texto      string
+		aceitas    []string
+		rejeitadas []string
+	}{
+		{
+			name:       "linear à direita: a*b ou a*",
+			texto:      "S -> aS | b | ε",
+			aceitas:    []string{"", "a", "b", "ab", "aaab"},
+			rejeitadas: []string{"ba", "bb", "abb"},
+		},
+		{
+			name: "linear à direita com vários terminais e comentários",
+			texto: `# termina com "ab"
+S -> aS | bS
+S -> abA1
+A1 -> ε`,
+			aceitas:    []string{"ab", "aab", "bab"},
+			rejeitadas: []string{"", "a", "ba", "abb"},
+		},
+		{
+			name:       "linear à esquerda: ba*",
+			texto:      "S -> Sa | b",
+			aceitas:    []string{"b", "ba", "baaa"},
+			rejeitadas: []string{"", "a", "ab", "bab"},
+		},
+		{
+			name: "linear à esquerda com unitária: (ab)+c",
+			texto: `S -> Ac
+A -> Bab | ab
+B -> A`,
+			aceitas:    []string{"abc", "ababc"},
+			rejeitadas: []string{"", "c", "abac", "ab"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			G, err := lerGramatica(tt.texto)
+			if err != nil {
+				t.Fatalf("lerGramatica() erro inesperado: %v", err)
+			}
+			AF := G.paraAutomato()
+			for _, cadeia := range tt.aceitas {
+				AF.adicionarCadeia(cadeia)
+				if !AF.funcionamento() {
+					t.Errorf("cadeia \"%s\" deveria ser aceita", cadeia)
+				}
+			}
+			for _, cadeia := range tt.rejeitadas {
+				AF.adicionarCadeia(cadeia)
+				if AF.funcionamento() {
+					t.Errorf("cadeia \"%s\" não deveria ser aceita", cadeia)
+				}
+			}
+		})
+	}
+}
+
+func TestLerGramaticaErros(t *testing.T) {
+	tests := []struct {
+		name     string
+		texto    string
+		expected string
+	}{
+		{"sem seta", "S aA", "linha 1"},
+		{"lado esquerdo inválido", "S -> b\n\na -> b", "linha 3"},
+		{"variável no meio", "S -> aAb", "no meio"},
+		{"duas variáveis", "S -> AB", "mais de uma variável"},
+		{"mistura direita e esquerda", "S -> aA\nA -> Sb", "mistura"},
+		{"alternativa vazia", "S -> a |", "alternativa vazia"},
+		{"∅ com outras alternativas", "S -> a | ∅", "∅ deve aparecer sozinho"},
+		{"barra invertida no fim", `S -> a\`, "barra invertida"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := lerGramatica(tt.texto)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("lerGramatica(%q) erro = %v, want contendo %q", tt.texto, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParaGramatica(t *testing.T) {
+	// a*b com épsilon, igual ao exemplo do menu
+	nfa := AutomatoFinito{
+		Estados:  []string{"qe0", "qe1", "qe2"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"qe1": {'a': {"qe1"}, 'b': {"qe2"}},
+		},
+		TransicoesEpsilon: map[string][]string{"qe0": {"qe1"}},
+		EstadoInicial:     "qe0",
+		EstadosFinais:     []string{"qe2"},
+	}
+
+	G := paraGramatica(&nfa)
+	expected := "S -> A\nA -> aA | bB\nB -> ε\n"
+	if got := G.String(); got != expected {
+		t.Errorf("String() =\n%s\nwant\n%s", got, expected)
+	}
+
+	// Ida e volta pelo texto preserva a linguagem
+	relida, err := lerGramatica(G.String())
+	if err != nil {
+		t.Fatalf("lerGramatica() erro inesperado: %v", err)
+	}
+	AF := relida.paraAutomato()
+	for _, cadeia := range []string{"", "a", "b", "ab", "aab", "ba", "abb"} {
+		nfa.adicionarCadeia(cadeia)
+		AF.adicionarCadeia(cadeia)
+		if got, want := AF.funcionamento(), nfa.funcionamento(); got != want {
+			t.Errorf("cadeia \"%s\": gramática = %v, autômato = %v", cadeia, got, want)
+		}
+	}
+}
+
+func TestGramaticaIdaEVolta(t *testing.T) {
+	vazia := AutomatoFinito{
+		Estados:       []string{"q0", "q1"},
+		Alfabeto:      []rune{'a'},
+		Transicoes:    map[string]map[rune][]string{"q1": {'a': {"q1"}}},
+		EstadoInicial: "q0",
+		EstadosFinais: []string{"q1"},
+	}
+	especiais := AutomatoFinito{
+		Estados:  []string{"q0", "q1"},
+		Alfabeto: []rune{'A', '|', ' ', 'ε', '∅', '\\', '1'},
+		Transicoes: map[string]map[rune][]string{
+			"q0": {'A': {"q0"}, '|': {"q1"}, ' ': {"q1"}, 'ε': {"q1"}, '∅': {"q1"}, '\\': {"q1"}},
+			"q1": {'1': {"q1"}},
+		},
+		EstadoInicial: "q0",
+		EstadosFinais: []string{"q1"},
+	}
+	tests := []struct {
+		name     string
+		af       *AutomatoFinito
+		expected string
+		cadeias  []string
+	}{
+		{"linguagem vazia", &vazia, "S -> ∅\nA -> aA | ε\n", []string{"", "a", "aa"}},
+		{"terminais especiais", &especiais, "S -> \\sA | \\AS | \\\\A | \\|A | \\εA | \\∅A\nA -> 1A | ε\n",
+			[]string{"", "A", "|", "AA|1", " 11", "ε", "∅1", "\\", "1", "A"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			G := paraGramatica(tt.af)
+			if got := G.String(); got != tt.expected {
+				t.Errorf("String() =\n%s\nwant\n%s", got, tt.expected)
+			}
+			relida, err := lerGramatica(G.String())
+			if err != nil {
+				t.Fatalf("lerGramatica() erro inesperado: %v", err)
+			}
+			AF := relida.paraAutomato()
+			for _, cadeia := range tt.cadeias {
+				tt.af.adicionarCadeia(cadeia)
+				AF.adicionarCadeia(cadeia)
+				if got, want := AF.funcionamento(), tt.af.funcionamento(); got != want {
+					t.Errorf("cadeia %q: gramática = %v, autômato = %v", cadeia, got, want)
+				}
+			}
+		})
+	}
+
+	// À esquerda, um dígito logo depois da variável faria parte do nome dela
+	G := Gramatica{
+		Inicial:        "S",
+		Variaveis:      []string{"S", "A"},
+		Terminais:      []rune{'0', '1'},
+		Producoes:      map[string][]Producao{"S": {{Terminais: []rune{'1'}, Variavel: "A"}}, "A": {{Terminais: []rune{'0'}}}},
+		LinearEsquerda: true,
+	}
+	if expected := "S -> A\\1\nA -> 0\n"; G.String() != expected {
+		t.Errorf("String() =\n%s\nwant\n%s", G.String(), expected)
+	}
+	relida, err := lerGramatica(G.String())
+	if err != nil {
+		t.Fatalf("lerGramatica() erro inesperado: %v", err)
+	}
+	if !reflect.DeepEqual(relida.Producoes, G.Producoes) {
+		t.Errorf("lerGramatica(String()) Producoes = %v, want %v", relida.Producoes, G.Producoes)
+	}
+}
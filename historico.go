@@ -0,0 +1,281 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Comando é uma mutação do autômato registrada no histórico da sessão. Na forma de script, cada
+// comando é uma linha com o nome seguido dos argumentos codificados por codificarCadeia:
+//
+//	state q0            adiciona o estado q0
+//	delstate q0         remove q0 e suas transições
+//	rename q0 inicio    renomeia q0 para inicio
+//	symbol a            adiciona o símbolo a ao alfabeto
+//	trans q0 a q1       adiciona a transição q0,a --> q1 (ε, eps ou epsilon para épsilon)
+//	deltrans q0 a q1    remove a transição q0,a --> q1
+//	start q0            define q0 como estado inicial
+//	final q1            marca q1 como final
+//	nonfinal q1         desmarca q1 como final
+type Comando struct {
+	Nome string
+	Args []string
+}
+
+var aridadeComandos = map[string]int{
+	"state": 1, "delstate": 1, "rename": 2, "symbol": 1,
+	"trans": 3, "deltrans": 3, "start": 1, "final": 1, "nonfinal": 1,
+}
+
+// Sessao guarda o autômato em construção e o histórico de comandos que o produziu a partir de base.
+// Desfazer recria o autômato executando de novo o histórico sem o último comando.
+type Sessao struct {
+	Automato  AutomatoFinito
+	base      AutomatoFinito
+	historico []Comando
+	desfeitos []Comando // os mais recentes no fim
+}
+
+// novaSessao começa uma sessão a partir de uma cópia do autômato dado.
+func novaSessao(AF AutomatoFinito) *Sessao {
+	return &Sessao{Automato: AF.clonar(), base: AF.clonar()}
+}
+
+// clonar copia o autômato sem compartilhar slices ou mapas com o original.
+func (AF *Automato[S]) clonar() Automato[S] {
+	copia := Automato[S]{
+		Estados:       slices.Clone(AF.Estados),
+		Alfabeto:      slices.Clone(AF.Alfabeto),
+		EstadoInicial: AF.EstadoInicial,
+		EstadosFinais: slices.Clone(AF.EstadosFinais),
+		Cadeia:        slices.Clone(AF.Cadeia),
+	}
+	if AF.TransicoesEpsilon != nil {
+		copia.TransicoesEpsilon = make(map[string][]string, len(AF.TransicoesEpsilon))
+		for origem, destinos := range AF.TransicoesEpsilon {
+			copia.TransicoesEpsilon[origem] = slices.Clone(destinos)
+		}
+	}
+	if AF.Transicoes != nil {
+		copia.Transicoes = make(map[string]map[S][]string, len(AF.Transicoes))
+		for origem, m := range AF.Transicoes {
+			copia.Transicoes[origem] = maps.Clone(m)
+			for simbolo, destinos := range m {
+				copia.Transicoes[origem][simbolo] = slices.Clone(destinos)
+			}
+		}
+	}
+	return copia
+}
+
+func (C Comando) String() string {
+	partes := []string{C.Nome}
+	for _, arg := range C.Args {
+		partes = append(partes, codificarCadeia(arg))
+	}
+	return strings.Join(partes, " ")
+}
+
+// lerComando interpreta uma linha de script. Os argumentos são separados por espaços e decodificados
+// por decodificarCadeia, então um nome com espaço é escrito com \s.
+func lerComando(linha string) (Comando, error) {
+	campos := strings.Fields(linha)
+	if len(campos) == 0 {
+		return Comando{}, errors.New("comando vazio")
+	}
+	aridade, ok := aridadeComandos[campos[0]]
+	if !ok {
+		return Comando{}, fmt.Errorf("comando desconhecido %q", campos[0])
+	}
+	if len(campos)-1 != aridade {
+		return Comando{}, fmt.Errorf("%s espera %d argumento(s), recebeu %d", campos[0], aridade, len(campos)-1)
+	}
+	comando := Comando{Nome: campos[0]}
+	for _, campo := range campos[1:] {
+		arg, err := decodificarCadeia(campo)
+		if err != nil {
+			return Comando{}, err
+		}
+		comando.Args = append(comando.Args, arg)
+	}
+	return comando, nil
+}
+
+// simboloComando converte o argumento de símbolo de trans/deltrans. A cadeia vazia (ε no script),
+// "eps" e "epsilon" indicam uma transição épsilon; o símbolo ε do alfabeto é escrito \ε.
+func simboloComando(texto string) (simbolo rune, epsilon bool, err error) {
+	if texto == "" || texto == "eps" || texto == "epsilon" {
+		return 0, true, nil
+	}
+	r := []rune(texto)
+	if len(r) != 1 {
+		return 0, false, fmt.Errorf("símbolo '%s' deve ser um único caractere ou \"eps\"/\"epsilon\"", texto)
+	}
+	return r[0], false, nil
+}
+
+// aplicar valida o comando contra o autômato e o executa. Em caso de erro o autômato não muda.
+func aplicar(AF *AutomatoFinito, comando Comando) error {
+	if aridade, ok := aridadeComandos[comando.Nome]; !ok || len(comando.Args) != aridade {
+		return fmt.Errorf("comando inválido: %s", comando)
+	}
+	existe := func(estado string) error {
+		if !slices.Contains(AF.Estados, estado) {
+			return fmt.Errorf("estado '%s' não existe", estado)
+		}
+		return nil
+	}
+	args := comando.Args
+
+	switch comando.Nome {
+	case "state":
+		if args[0] == "" {
+			return errors.New("nome do estado não pode ser vazio")
+		}
+		if slices.Contains(AF.Estados, args[0]) {
+			return fmt.Errorf("estado '%s' já existe", args[0])
+		}
+		AF.adicionarEstado(args[0])
+	case "delstate":
+		return AF.removerEstado(args[0])
+	case "rename":
+		return AF.renomearEstado(args[0], args[1])
+	case "symbol":
+		r := []rune(args[0])
+		switch {
+		case len(r) != 1:
+			return fmt.Errorf("símbolo '%s' deve ser um único caractere", args[0])
+		case slices.Contains(AF.Alfabeto, r[0]):
+			return fmt.Errorf("símbolo '%c' já está no alfabeto", r[0])
+		}
+		AF.adicionarAlfabeto(r[0])
+	case "trans", "deltrans":
+		simbolo, epsilon, err := simboloComando(args[1])
+		if err != nil {
+			return err
+		}
+		switch {
+		case comando.Nome == "deltrans" && epsilon:
+			return AF.removerTransicaoEpsilon(args[0], args[2])
+		case comando.Nome == "deltrans":
+			return AF.removerTransicao(args[0], simbolo, args[2])
+		}
+		if err := errors.Join(existe(args[0]), existe(args[2])); err != nil {
+			return err
+		}
+		if epsilon {
+			if slices.Contains(AF.TransicoesEpsilon[args[0]], args[2]) {
+				return fmt.Errorf("transição %s,ε --> %s já existe", args[0], args[2])
+			}
+			AF.adicionarTransicaoEpsilon(args[0], args[2])
+			return nil
+		}
+		if !slices.Contains(AF.Alfabeto, simbolo) {
+			return fmt.Errorf("símbolo '%c' não está no alfabeto", simbolo)
+		}
+		if slices.Contains(AF.Transicoes[args[0]][simbolo], args[2]) {
+			return fmt.Errorf("transição %s,%q --> %s já existe", args[0], simbolo, args[2])
+		}
+		AF.adicionarTransicao(args[0], simbolo, args[2])
+	case "start":
+		if err := existe(args[0]); err != nil {
+			return err
+		}
+		AF.adicionarEstadoInicial(args[0])
+	case "final", "nonfinal":
+		if err := existe(args[0]); err != nil {
+			return err
+		}
+		if slices.Contains(AF.EstadosFinais, args[0]) == (comando.Nome == "final") {
+			if comando.Nome == "final" {
+				return fmt.Errorf("estado '%s' já é final", args[0])
+			}
+			return fmt.Errorf("estado '%s' não é final", args[0])
+		}
+		_, err := AF.alternarEstadoFinal(args[0])
+		return err
+	}
+	return nil
+}
+
+// executar aplica o comando e o registra no histórico. Um novo comando descarta o que foi desfeito.
+func (S *Sessao) executar(comando Comando) error {
+	if err := aplicar(&S.Automato, comando); err != nil {
+		return err
+	}
+	if comando.Nome == "trans" || comando.Nome == "deltrans" {
+		if _, epsilon, _ := simboloComando(comando.Args[1]); epsilon {
+			// Todas as formas de épsilon ficam registradas como ε no script
+			comando.Args = []string{comando.Args[0], "", comando.Args[2]}
+		}
+	}
+	S.historico = append(S.historico, comando)
+	S.desfeitos = nil
+	return nil
+}
+
+// desfazer retira o último comando do histórico e retorna qual foi.
+func (S *Sessao) desfazer() (Comando, error) {
+	if len(S.historico) == 0 {
+		return Comando{}, errors.New("nada para desfazer")
+	}
+	ultimo := S.historico[len(S.historico)-1]
+	S.historico = S.historico[:len(S.historico)-1]
+	S.desfeitos = append(S.desfeitos, ultimo)
+
+	S.Automato = S.base.clonar()
+	for _, comando := range S.historico {
+		if err := aplicar(&S.Automato, comando); err != nil {
+			// O histórico só contém comandos que já foram aplicados com sucesso nesta ordem
+			panic(fmt.Sprintf("histórico inconsistente em %q: %v", comando, err))
+		}
+	}
+	return ultimo, nil
+}
+
+// refazer executa de novo o último comando desfeito.
+func (S *Sessao) refazer() (Comando, error) {
+	if len(S.desfeitos) == 0 {
+		return Comando{}, errors.New("nada para refazer")
+	}
+	comando := S.desfeitos[len(S.desfeitos)-1]
+	if err := aplicar(&S.Automato, comando); err != nil {
+		return Comando{}, err
+	}
+	S.desfeitos = S.desfeitos[:len(S.desfeitos)-1]
+	S.historico = append(S.historico, comando)
+	return comando, nil
+}
+
+// script retorna o histórico com um comando por linha, no formato lido por executarScript.
+func (S *Sessao) script() string {
+	var sb strings.Builder
+	for _, comando := range S.historico {
+		sb.WriteString(comando.String())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// executarScript reconstrói um autômato a partir de um script, ignorando linhas vazias e comentários
+// iniciados por #. O histórico da sessão retornada é o próprio script, que pode então ser desfeito.
+func executarScript(texto string) (*Sessao, error) {
+	sessao := novaSessao(AutomatoFinito{})
+	for i, linha := range strings.Split(texto, "\n") {
+		linha = strings.TrimSpace(linha)
+		if linha == "" || strings.HasPrefix(linha, "#") {
+			continue
+		}
+		comando, err := lerComando(linha)
+		if err == nil {
+			err = sessao.executar(comando)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("linha %d: %w", i+1, err)
+		}
+	}
+	return sessao, nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSessaoDesfazerRefazer(t *testing.T) {
+	sessao := novaSessao(AutomatoFinito{})
+	for _, linha := range []string{"state q0", "state q1", "symbol a", "start q0", "trans q0 a q1", "final q1"} {
+		comando, err := lerComando(linha)
+		if err != nil {
+			t.Fatalf("lerComando(%q) erro inesperado: %v", linha, err)
+		}
+		if err := sessao.executar(comando); err != nil {
+			t.Fatalf("executar(%q) erro inesperado: %v", linha, err)
+		}
+	}
+	completo := sessao.Automato.clonar()
+
+	for range 2 {
+		if _, err := sessao.desfazer(); err != nil {
+			t.Fatalf("desfazer() erro inesperado: %v", err)
+		}
+	}
+	if len(sessao.Automato.Transicoes) != 0 || len(sessao.Automato.EstadosFinais) != 0 {
+		t.Errorf("após desfazer duas vezes: %+v, want sem transições e sem finais", sessao.Automato)
+	}
+
+	comando, err := sessao.refazer()
+	if err != nil || comando.String() != "trans q0 a q1" {
+		t.Errorf("refazer() = %q, %v, want \"trans q0 a q1\"", comando, err)
+	}
+	if _, err := sessao.refazer(); err != nil {
+		t.Fatalf("refazer() erro inesperado: %v", err)
+	}
+	if !reflect.DeepEqual(sessao.Automato, completo) {
+		t.Errorf("após refazer: %+v, want %+v", sessao.Automato, completo)
+	}
+	if _, err := sessao.refazer(); err == nil {
+		t.Errorf("refazer() sem comandos desfeitos deveria falhar")
+	}
+
+	// Um comando novo descarta o que foi desfeito
+	sessao.desfazer()
+	sessao.executar(Comando{"final", []string{"q0"}})
+	if _, err := sessao.refazer(); err == nil {
+		t.Errorf("refazer() após novo comando deveria falhar")
+	}
+
+	// Um comando inválido não entra no histórico
+	antes := sessao.script()
+	if err := sessao.executar(Comando{"trans", []string{"q0", "b", "q1"}}); err == nil {
+		t.Errorf("trans com símbolo fora do alfabeto deveria falhar")
+	}
+	if sessao.script() != antes {
+		t.Errorf("script mudou após comando inválido:\n%s", sessao.script())
+	}
+}
+
+func TestScriptIdaEVolta(t *testing.T) {
+	sessao := novaSessao(AutomatoFinito{})
+	comandos := []Comando{
+		{"state", []string{"estado inicial"}},
+		{"state", []string{"q\\1"}},
+		{"symbol", []string{" "}},
+		{"symbol", []string{"ε"}},
+		{"start", []string{"estado inicial"}},
+		{"trans", []string{"estado inicial", "eps", "q\\1"}},
+		{"trans", []string{"q\\1", " ", "q\\1"}},
+		{"trans", []string{"q\\1", "ε", "estado inicial"}},
+		{"final", []string{"q\\1"}},
+		{"rename", []string{"q\\1", "fim"}},
+	}
+	for _, comando := range comandos {
+		if err := sessao.executar(comando); err != nil {
+			t.Fatalf("executar(%q) erro inesperado: %v", comando, err)
+		}
+	}
+
+	script := sessao.script()
+	if !strings.Contains(script, "trans estado\\sinicial ε q\\\\1\n") {
+		t.Errorf("script() sem a transição épsilon codificada:\n%s", script)
+	}
+	if !strings.Contains(script, "trans q\\\\1 \\ε estado\\sinicial\n") {
+		t.Errorf("script() sem a transição com o símbolo ε:\n%s", script)
+	}
+	reconstruida, err := executarScript("# gerado pelo teste\n\n" + script)
+	if err != nil {
+		t.Fatalf("executarScript() erro inesperado: %v", err)
+	}
+	if !reflect.DeepEqual(reconstruida.Automato, sessao.Automato) {
+		t.Errorf("executarScript() = %+v, want %+v", reconstruida.Automato, sessao.Automato)
+	}
+	if reconstruida.script() != script {
+		t.Errorf("script da sessão reconstruída = %q, want %q", reconstruida.script(), script)
+	}
+}
+
+func TestExecutarScriptErros(t *testing.T) {
+	tests := []struct {
+		script   string
+		expected string
+	}{
+		{"state q0\nstate q0\n", "linha 2: estado 'q0' já existe"},
+		{"state q0\n\nfoo q0\n", "linha 3: comando desconhecido"},
+		{"trans q0 a\n", "linha 1: trans espera 3 argumento(s)"},
+		{"state q0\nsymbol ab\n", "linha 2: símbolo 'ab'"},
+		{"state q0\nnonfinal q0\n", "linha 2: estado 'q0' não é final"},
+	}
+	for _, tt := range tests {
+		_, err := executarScript(tt.script)
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("executarScript(%q) erro = %v, want contendo %q", tt.script, err, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var errNaoDeterministico = errors.New("o autômato não é determinístico")
+
+// ehDeterministico informa se o autômato não tem transições épsilon nem mais de um destino por
+// (estado, símbolo). Transições ausentes são permitidas (AFD parcial).
+func (AF *Automato[S]) ehDeterministico() bool {
+	for _, destinos := range AF.TransicoesEpsilon {
+		if len(destinos) > 0 {
+			return false
+		}
+	}
+	for _, m := range AF.Transicoes {
+		for _, destinos := range m {
+			if len(destinos) > 1 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// simbolosOrdenados retorna, em ordem crescente e sem repetições, os símbolos do alfabeto
+// e os usados nas transições.
+func (AF *Automato[S]) simbolosOrdenados() []S {
+	simbolos := slices.Clone(AF.Alfabeto)
+	for _, m := range AF.Transicoes {
+		for simbolo := range m {
+			simbolos = append(simbolos, simbolo)
+		}
+	}
+	ordenarSimbolos(simbolos)
+	return slices.Compact(simbolos)
+}
+
+// ordemBFS retorna os estados alcançáveis a partir do estado inicial, na ordem em que uma busca
+// em largura os visita percorrendo os símbolos na ordem recebida.
+func (AF *Automato[S]) ordemBFS(simbolos []S) []string {
+	visitados := map[string]bool{AF.EstadoInicial: true}
+	ordem := []string{AF.EstadoInicial}
+	for i := 0; i < len(ordem); i++ {
+		for _, simbolo := range simbolos {
+			for _, destino := range AF.Transicoes[ordem[i]][simbolo] {
+				if !visitados[destino] {
+					visitados[destino] = true
+					ordem = append(ordem, destino)
+				}
+			}
+		}
+	}
+	return ordem
+}
+
+// renomearCanonico renomeia os estados de um AFD para q0, q1, ... na ordem da busca em largura a
+// partir do estado inicial, percorrendo o alfabeto ordenado. Estados inalcançáveis recebem os
+// últimos nomes, na ordem natural dos nomes originais. Retorna também o mapa nome antigo -> novo.
+func (AF *Automato[S]) renomearCanonico() (Automato[S], map[string]string, error) {
+	if !AF.ehDeterministico() {
+		return Automato[S]{}, nil, errNaoDeterministico
+	}
+
+	simbolos := AF.simbolosOrdenados()
+	ordem := AF.ordemBFS(simbolos)
+
+	var inalcancaveis []string
+	for _, estado := range AF.Estados {
+		if !slices.Contains(ordem, estado) {
+			inalcancaveis = append(inalcancaveis, estado)
+		}
+	}
+	ordenarEstados(inalcancaveis)
+	ordem = append(ordem, inalcancaveis...)
+
+	novoNome := make(map[string]string, len(ordem))
+	for i, estado := range ordem {
+		novoNome[estado] = fmt.Sprintf("q%d", i)
+	}
+
+	canonico := Automato[S]{Alfabeto: slices.Clone(simbolos)}
+	for _, estado := range ordem {
+		canonico.adicionarEstado(novoNome[estado])
+	}
+	canonico.adicionarEstadoInicial(novoNome[AF.EstadoInicial])
+	for _, estado := range ordem {
+		for _, simbolo := range simbolos {
+			for _, destino := range AF.Transicoes[estado][simbolo] {
+				canonico.adicionarTransicao(novoNome[estado], simbolo, novoNome[destino])
+			}
+		}
+		if slices.Contains(AF.EstadosFinais, estado) {
+			canonico.adicionarEstadoFinal(novoNome[estado])
+		}
+	}
+	return canonico, novoNome, nil
+}
+
+// isomorfismo verifica se dois AFDs são iguais a menos dos nomes dos estados, considerando a parte
+// alcançável a partir dos estados iniciais. Quando são, retorna a bijeção estado de AF -> estado de outro.
+func (AF *Automato[S]) isomorfismo(outro *Automato[S]) (map[string]string, bool, error) {
+	if !AF.ehDeterministico() || !outro.ehDeterministico() {
+		return nil, false, errNaoDeterministico
+	}
+
+	simbolos := append(AF.simbolosOrdenados(), outro.simbolosOrdenados()...)
+	ordenarSimbolos(simbolos)
+	simbolos = slices.Compact(simbolos)
+
+	bijecao := map[string]string{AF.EstadoInicial: outro.EstadoInicial}
+	inversa := map[string]string{outro.EstadoInicial: AF.EstadoInicial}
+	fila := []string{AF.EstadoInicial}
+	for len(fila) > 0 {
+		estado := fila[0]
+		fila = fila[1:]
+		correspondente := bijecao[estado]
+
+		if slices.Contains(AF.EstadosFinais, estado) != slices.Contains(outro.EstadosFinais, correspondente) {
+			return nil, false, nil
+		}
+
+		for _, simbolo := range simbolos {
+			destinos := AF.Transicoes[estado][simbolo]
+			destinosOutro := outro.Transicoes[correspondente][simbolo]
+			if len(destinos) != len(destinosOutro) {
+				return nil, false, nil
+			}
+			if len(destinos) == 0 {
+				continue
+			}
+
+			destino, destinoOutro := destinos[0], destinosOutro[0]
+			mapeado, jaVisto := bijecao[destino]
+			origemOutro, jaVistoOutro := inversa[destinoOutro]
+			switch {
+			case jaVisto && mapeado != destinoOutro, jaVistoOutro && origemOutro != destino:
+				return nil, false, nil
+			case !jaVisto:
+				bijecao[destino] = destinoOutro
+				inversa[destinoOutro] = destino
+				fila = append(fila, destino)
+			}
+		}
+	}
+	return bijecao, true, nil
+}
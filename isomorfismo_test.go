@@ -0,0 +1,122 @@
+package main
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestRenomearCanonico(t *testing.T) {
+	// Número par de 'a's, com nomes arbitrários e um estado inalcançável
+	dfa := AutomatoFinito{
+		Estados:  []string{"lixo", "impar", "par"},
+		Alfabeto: []rune{'b', 'a'},
+		Transicoes: map[string]map[rune][]string{
+			"par":   {'a': {"impar"}, 'b': {"par"}},
+			"impar": {'a': {"par"}, 'b': {"impar"}},
+			"lixo":  {'a': {"par"}},
+		},
+		EstadoInicial: "par",
+		EstadosFinais: []string{"par"},
+	}
+
+	canonico, novoNome, err := dfa.renomearCanonico()
+	if err != nil {
+		t.Fatalf("renomearCanonico() erro inesperado: %v", err)
+	}
+
+	expectedNomes := map[string]string{"par": "q0", "impar": "q1", "lixo": "q2"}
+	if !maps.Equal(novoNome, expectedNomes) {
+		t.Errorf("novoNome = %v, want %v", novoNome, expectedNomes)
+	}
+	if canonico.EstadoInicial != "q0" {
+		t.Errorf("EstadoInicial = %s, want q0", canonico.EstadoInicial)
+	}
+	if !slicesEqualIgnoringOrderAndDuplicates(canonico.EstadosFinais, []string{"q0"}) {
+		t.Errorf("EstadosFinais = %v, want [q0]", canonico.EstadosFinais)
+	}
+	if got := canonico.Transicoes["q1"]['a']; len(got) != 1 || got[0] != "q0" {
+		t.Errorf("δ(q1, a) = %v, want [q0]", got)
+	}
+
+	nfa := AutomatoFinito{
+		Estados:       []string{"q0", "q1"},
+		Transicoes:    map[string]map[rune][]string{"q0": {'a': {"q0", "q1"}}},
+		EstadoInicial: "q0",
+	}
+	if _, _, err := nfa.renomearCanonico(); err == nil {
+		t.Errorf("renomearCanonico() em AFN deveria retornar erro")
+	}
+}
+
+func TestIsomorfismo(t *testing.T) {
+	// Cadeias que terminam em 'b', com dois conjuntos de nomes diferentes
+	dfaAluno1 := AutomatoFinito{
+		Estados:  []string{"A", "B"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"A": {'a': {"A"}, 'b': {"B"}},
+			"B": {'a': {"A"}, 'b': {"B"}},
+		},
+		EstadoInicial: "A",
+		EstadosFinais: []string{"B"},
+	}
+	dfaAluno2 := AutomatoFinito{
+		Estados:  []string{"fim_b", "inicio"},
+		Alfabeto: []rune{'b', 'a'},
+		Transicoes: map[string]map[rune][]string{
+			"inicio": {'a': {"inicio"}, 'b': {"fim_b"}},
+			"fim_b":  {'a': {"inicio"}, 'b': {"fim_b"}},
+		},
+		EstadoInicial: "inicio",
+		EstadosFinais: []string{"fim_b"},
+	}
+	// Mesmo grafo, mas com o final trocado (termina em 'a')
+	dfaFinalTrocado := AutomatoFinito{
+		Estados:  []string{"A", "B"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"A": {'a': {"A"}, 'b': {"B"}},
+			"B": {'a': {"A"}, 'b': {"B"}},
+		},
+		EstadoInicial: "A",
+		EstadosFinais: []string{"A"},
+	}
+	// Transição 'b' de B ausente (AFD parcial)
+	dfaParcial := AutomatoFinito{
+		Estados:  []string{"A", "B"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"A": {'a': {"A"}, 'b': {"B"}},
+			"B": {'a': {"A"}},
+		},
+		EstadoInicial: "A",
+		EstadosFinais: []string{"B"},
+	}
+
+	tests := []struct {
+		name            string
+		a, b            *AutomatoFinito
+		expected        bool
+		expectedBijecao map[string]string
+	}{
+		{"mesmo autômato", &dfaAluno1, &dfaAluno1, true, map[string]string{"A": "A", "B": "B"}},
+		{"nomes diferentes", &dfaAluno1, &dfaAluno2, true, map[string]string{"A": "inicio", "B": "fim_b"}},
+		{"finais diferentes", &dfaAluno1, &dfaFinalTrocado, false, nil},
+		{"transição ausente", &dfaAluno1, &dfaParcial, false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bijecao, ok, err := tt.a.isomorfismo(tt.b)
+			if err != nil {
+				t.Fatalf("isomorfismo() erro inesperado: %v", err)
+			}
+			if ok != tt.expected {
+				t.Fatalf("isomorfismo() = %v, want %v", ok, tt.expected)
+			}
+			if ok && !maps.Equal(bijecao, tt.expectedBijecao) {
+				t.Errorf("bijeção = %v, want %v", bijecao, tt.expectedBijecao)
+			}
+		})
+	}
+}
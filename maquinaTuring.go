@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Movimento é o deslocamento da cabeça após uma transição da máquina de Turing.
+type Movimento rune
+
+const (
+	MovimentoEsquerda Movimento = 'L'
+	MovimentoDireita  Movimento = 'R'
+	MovimentoParado   Movimento = 'S'
+)
+
+type TransicaoTuring struct {
+	Escreve rune
+	Move    Movimento
+	Destino string
+}
+
+// MaquinaTuring é uma máquina de Turing determinística de fita única, infinita nos dois sentidos.
+type MaquinaTuring struct {
+	Estados       []string
+	Alfabeto      []rune // alfabeto de entrada
+	AlfabetoFita  []rune
+	Branco        rune
+	Transicoes    map[string]map[rune]TransicaoTuring // estadoOrigem: [símbolo lido: transição]
+	EstadoInicial string
+	EstadoAceita  string
+	EstadoRejeita string
+	LimitePassos  int // 0 usa limitePassosPadrao
+}
+
+// ResultadoTuring indica como a execução terminou.
+type ResultadoTuring int
+
+const (
+	TuringAceita  ResultadoTuring = iota
+	TuringRejeita                 // entrou no estado de rejeição
+	TuringParada                  // parou sem transição fora dos estados de aceitação/rejeição
+	TuringLimite                  // atingiu o limite de passos sem parar
+)
+
+func (R ResultadoTuring) String() string {
+	switch R {
+	case TuringAceita:
+		return "aceita"
+	case TuringRejeita:
+		return "rejeita"
+	case TuringParada:
+		return "parada"
+	default:
+		return "limite de passos atingido"
+	}
+}
+
+// ExecucaoTuring guarda o resultado, o número de passos e a fita final (sem brancos nas pontas).
+type ExecucaoTuring struct {
+	Resultado ResultadoTuring
+	Passos    int
+	Estado    string
+	Fita      string
+}
+
+func (MT *MaquinaTuring) adicionarEstado(estado string) {
+	MT.Estados = append(MT.Estados, estado)
+}
+
+func (MT *MaquinaTuring) adicionarTransicao(estadoOrigem string, le rune, estadoDestino string, escreve rune, move Movimento) {
+	if MT.Transicoes == nil {
+		MT.Transicoes = make(map[string]map[rune]TransicaoTuring)
+	}
+	if MT.Transicoes[estadoOrigem] == nil {
+		MT.Transicoes[estadoOrigem] = make(map[rune]TransicaoTuring)
+	}
+	MT.Transicoes[estadoOrigem][le] = TransicaoTuring{escreve, move, estadoDestino}
+}
+
+// fitaTuring é a fita infinita nos dois sentidos: celulas[i] é a posição i+origem.
+type fitaTuring struct {
+	celulas []rune
+	origem  int
+	branco  rune
+}
+
+func (F *fitaTuring) ler(posicao int) rune {
+	i := posicao - F.origem
+	if i < 0 || i >= len(F.celulas) {
+		return F.branco
+	}
+	return F.celulas[i]
+}
+
+func (F *fitaTuring) escrever(posicao int, simbolo rune) {
+	for posicao < F.origem {
+		F.celulas = append([]rune{F.branco}, F.celulas...)
+		F.origem--
+	}
+	for posicao-F.origem >= len(F.celulas) {
+		F.celulas = append(F.celulas, F.branco)
+	}
+	F.celulas[posicao-F.origem] = simbolo
+}
+
+// trecho retorna o conteúdo entre a primeira e a última célula não branca, estendido até incluir
+// a cabeça, e o índice da cabeça nesse trecho.
+func (F *fitaTuring) trecho(cabeca int) ([]rune, int) {
+	inicio, fim := cabeca, cabeca
+	for i, simbolo := range F.celulas {
+		if simbolo != F.branco {
+			inicio = min(inicio, i+F.origem)
+			fim = max(fim, i+F.origem)
+		}
+	}
+	var trecho []rune
+	for posicao := inicio; posicao <= fim; posicao++ {
+		trecho = append(trecho, F.ler(posicao))
+	}
+	return trecho, cabeca - inicio
+}
+
+// executar roda a máquina sobre a entrada até parar ou atingir o limite de passos. Se trace não
+// for nil, escreve nele o estado e a fita (com a cabeça entre colchetes) antes de cada passo e ao final.
+func (MT *MaquinaTuring) executar(entrada string, trace io.Writer) ExecucaoTuring {
+	limite := MT.LimitePassos
+	if limite <= 0 {
+		limite = limitePassosPadrao
+	}
+
+	fita := fitaTuring{celulas: []rune(entrada), branco: MT.Branco}
+	estado, cabeca := MT.EstadoInicial, 0
+	var execucao ExecucaoTuring
+	for ; ; execucao.Passos++ {
+		if trace != nil {
+			trecho, posicao := fita.trecho(cabeca)
+			fmt.Fprintf(trace, "%4d %s: %s\n", execucao.Passos, estado, formatarFita(trecho, posicao))
+		}
+
+		if estado == MT.EstadoAceita {
+			execucao.Resultado = TuringAceita
+			break
+		}
+		if estado == MT.EstadoRejeita {
+			execucao.Resultado = TuringRejeita
+			break
+		}
+		transicao, ok := MT.Transicoes[estado][fita.ler(cabeca)]
+		if !ok {
+			execucao.Resultado = TuringParada
+			break
+		}
+		if execucao.Passos == limite {
+			execucao.Resultado = TuringLimite
+			break
+		}
+
+		fita.escrever(cabeca, transicao.Escreve)
+		switch transicao.Move {
+		case MovimentoEsquerda:
+			cabeca--
+		case MovimentoDireita:
+			cabeca++
+		}
+		estado = transicao.Destino
+	}
+
+	trecho, _ := fita.trecho(cabeca)
+	execucao.Estado = estado
+	execucao.Fita = strings.Trim(string(trecho), string(MT.Branco))
+	return execucao
+}
+
+func formatarFita(trecho []rune, cabeca int) string {
+	var sb strings.Builder
+	for i, simbolo := range trecho {
+		if i == cabeca {
+			fmt.Fprintf(&sb, "[%c]", simbolo)
+		} else {
+			sb.WriteRune(simbolo)
+		}
+	}
+	return sb.String()
+}
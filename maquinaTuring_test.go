@@ -0,0 +1,70 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// novaMaquinaAnBn retorna uma máquina que aceita a^n b^n marcando cada 'a' com X e cada 'b' com Y.
+func novaMaquinaAnBn() MaquinaTuring {
+	MT := MaquinaTuring{Branco: '_', EstadoInicial: "q0", EstadoAceita: "qa", EstadoRejeita: "qr"}
+	for _, estado := range []string{"q0", "q1", "q2", "q3", "qa", "qr"} {
+		MT.adicionarEstado(estado)
+	}
+	MT.adicionarTransicao("q0", 'a', "q1", 'X', MovimentoDireita)
+	MT.adicionarTransicao("q0", 'Y', "q3", 'Y', MovimentoDireita)
+	MT.adicionarTransicao("q0", '_', "qa", '_', MovimentoParado)
+	MT.adicionarTransicao("q0", 'b', "qr", 'b', MovimentoParado)
+	MT.adicionarTransicao("q1", 'a', "q1", 'a', MovimentoDireita)
+	MT.adicionarTransicao("q1", 'Y', "q1", 'Y', MovimentoDireita)
+	MT.adicionarTransicao("q1", 'b', "q2", 'Y', MovimentoEsquerda)
+	MT.adicionarTransicao("q2", 'a', "q2", 'a', MovimentoEsquerda)
+	MT.adicionarTransicao("q2", 'Y', "q2", 'Y', MovimentoEsquerda)
+	MT.adicionarTransicao("q2", 'X', "q0", 'X', MovimentoDireita)
+	MT.adicionarTransicao("q3", 'Y', "q3", 'Y', MovimentoDireita)
+	MT.adicionarTransicao("q3", '_', "qa", '_', MovimentoParado)
+	return MT
+}
+
+func TestExecutarTuring(t *testing.T) {
+	MT := novaMaquinaAnBn()
+	tests := []struct {
+		entrada      string
+		expected     ResultadoTuring
+		expectedFita string
+	}{
+		{"", TuringAceita, ""},
+		{"ab", TuringAceita, "XY"},
+		{"aaabbb", TuringAceita, "XXXYYY"},
+		{"ba", TuringRejeita, "ba"},
+		{"aab", TuringParada, "XXY"}, // q1 encontra o branco sem 'b'
+		{"abb", TuringParada, "XYb"}, // q3 encontra 'b' depois dos Y
+	}
+	for _, tt := range tests {
+		execucao := MT.executar(tt.entrada, nil)
+		if execucao.Resultado != tt.expected || execucao.Fita != tt.expectedFita {
+			t.Errorf("executar(%q) = (%v, %q), want (%v, %q)", tt.entrada, execucao.Resultado, execucao.Fita, tt.expected, tt.expectedFita)
+		}
+	}
+}
+
+func TestExecutarTuringLimiteETrace(t *testing.T) {
+	// Anda para a direita para sempre
+	MT := MaquinaTuring{Branco: '_', EstadoInicial: "q0", EstadoAceita: "qa", LimitePassos: 3}
+	MT.adicionarTransicao("q0", '_', "q0", '1', MovimentoDireita)
+
+	var trace strings.Builder
+	execucao := MT.executar("", &trace)
+	if execucao.Resultado != TuringLimite || execucao.Passos != 3 {
+		t.Errorf("executar() = (%v, %d passos), want (%v, 3 passos)", execucao.Resultado, execucao.Passos, TuringLimite)
+	}
+
+	expected := "" +
+		"   0 q0: [_]\n" +
+		"   1 q0: 1[_]\n" +
+		"   2 q0: 11[_]\n" +
+		"   3 q0: 111[_]\n"
+	if trace.String() != expected {
+		t.Errorf("trace =\n%s\nwant\n%s", trace.String(), expected)
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// parEstados é um par não ordenado de estados, guardado sempre na ordem das linhas da tabela.
+type parEstados struct {
+	p, q string
+}
+
+// TabelaDistinguibilidade é o resultado do algoritmo de preenchimento de tabela (Myhill–Nerode)
+// sobre um AFD. Para cada par distinguível guarda o menor sufixo que leva exatamente um dos dois
+// estados a um estado final; pares ausentes de Sufixos são equivalentes.
+type TabelaDistinguibilidade struct {
+	Estados []string
+	Sufixos map[parEstados]string
+}
+
+// tabelaDistinguibilidade preenche a tabela de pares distinguíveis de um AFD. Transições ausentes
+// levam a um estado morto implícito, que só participa do cálculo e não aparece na tabela.
+// Os pares são marcados em rodadas: na rodada k são marcados os pares separados por um sufixo de
+// tamanho k, o que garante que o sufixo registrado é o menor (e, entre os menores, o primeiro
+// na ordem do alfabeto).
+func (AF *Automato[S]) tabelaDistinguibilidade() (TabelaDistinguibilidade, error) {
+	if !AF.ehDeterministico() {
+		return TabelaDistinguibilidade{}, errNaoDeterministico
+	}
+
+	const estadoMorto = "" // Nenhum estado do usuário pode ter nome vazio
+	estados := append(append([]string{}, AF.Estados...), estadoMorto)
+	simbolos := AF.simbolosOrdenados()
+	indice := make(map[string]int, len(estados))
+	for i, estado := range estados {
+		indice[estado] = i
+	}
+	par := func(a, b string) parEstados {
+		if indice[a] > indice[b] {
+			a, b = b, a
+		}
+		return parEstados{a, b}
+	}
+	proximo := func(estado string, simbolo S) string {
+		if destinos := AF.Transicoes[estado][simbolo]; len(destinos) > 0 {
+			return destinos[0]
+		}
+		return estadoMorto
+	}
+	ehFinal := func(estado string) bool {
+		return estado != estadoMorto && slices.Contains(AF.EstadosFinais, estado)
+	}
+
+	sufixos := make(map[parEstados]string)
+	for i, p := range estados {
+		for _, q := range estados[:i] {
+			if ehFinal(p) != ehFinal(q) {
+				sufixos[par(p, q)] = ""
+			}
+		}
+	}
+
+	for mudou := true; mudou; {
+		mudou = false
+		marcadosNaRodada := make(map[parEstados]string)
+		for i, p := range estados {
+			for _, q := range estados[:i] {
+				if _, marcado := sufixos[par(p, q)]; marcado {
+					continue
+				}
+				for _, simbolo := range simbolos {
+					if sufixo, ok := sufixos[par(proximo(p, simbolo), proximo(q, simbolo))]; ok {
+						marcadosNaRodada[par(p, q)] = prefixarEntrada(simbolo, sufixo)
+						break
+					}
+				}
+			}
+		}
+		for chave, sufixo := range marcadosNaRodada {
+			sufixos[chave] = sufixo
+			mudou = true
+		}
+	}
+
+	tabela := TabelaDistinguibilidade{
+		Estados: append([]string{}, AF.Estados...),
+		Sufixos: make(map[parEstados]string),
+	}
+	for chave, sufixo := range sufixos {
+		if chave.p != estadoMorto && chave.q != estadoMorto {
+			tabela.Sufixos[chave] = sufixo
+		}
+	}
+	return tabela, nil
+}
+
+// distinguiveis informa se p e q são distinguíveis e, nesse caso, o menor sufixo que os separa.
+func (T *TabelaDistinguibilidade) distinguiveis(p, q string) (string, bool) {
+	if sufixo, ok := T.Sufixos[parEstados{p, q}]; ok {
+		return sufixo, true
+	}
+	sufixo, ok := T.Sufixos[parEstados{q, p}]
+	return sufixo, ok
+}
+
+// String desenha a tabela triangular: a célula (linha p, coluna q) mostra o sufixo que distingue
+// os dois estados ("ε" quando um é final e o outro não) ou "≡" quando são equivalentes.
+func (T TabelaDistinguibilidade) String() string {
+	if len(T.Estados) < 2 {
+		return ""
+	}
+	linhas := T.Estados[1:]
+	colunas := T.Estados[:len(T.Estados)-1]
+
+	celula := func(p, q string) string {
+		sufixo, ok := T.distinguiveis(p, q)
+		switch {
+		case !ok:
+			return "≡"
+		case sufixo == "":
+			return string(epsilonRune)
+		default:
+			return sufixo
+		}
+	}
+
+	larguraRotulo := 0
+	for _, estado := range linhas {
+		larguraRotulo = max(larguraRotulo, utf8.RuneCountInString(estado))
+	}
+	larguras := make([]int, len(colunas))
+	for j, q := range colunas {
+		larguras[j] = utf8.RuneCountInString(q)
+		for _, p := range linhas[j:] {
+			larguras[j] = max(larguras[j], utf8.RuneCountInString(celula(p, q)))
+		}
+	}
+	preencher := func(texto string, largura int) string {
+		return texto + strings.Repeat(" ", largura-utf8.RuneCountInString(texto))
+	}
+
+	var sb strings.Builder
+	for i, p := range linhas {
+		linha := preencher(p, larguraRotulo) + " |"
+		for j, q := range colunas[:i+1] {
+			linha += " " + preencher(celula(p, q), larguras[j])
+		}
+		sb.WriteString(strings.TrimRight(linha, " ") + "\n")
+	}
+	rodape := strings.Repeat(" ", larguraRotulo) + " |"
+	for j, q := range colunas {
+		rodape += " " + preencher(q, larguras[j])
+	}
+	sb.WriteString(strings.TrimRight(rodape, " ") + "\n")
+	return sb.String()
+}
+
+func exibicaoTabelaDistinguibilidade(AFUsuario *AutomatoFinito) {
+	tabela, err := AFUsuario.tabelaDistinguibilidade()
+	if err != nil {
+		fmt.Println("Erro:", err)
+		return
+	}
+	fmt.Println("\nTabela de distinguibilidade (Myhill–Nerode):")
+	fmt.Print(tabela)
+	fmt.Println("ε: distinguidos pela cadeia vazia; ≡: estados equivalentes")
+}
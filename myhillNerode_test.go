@@ -0,0 +1,85 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestTabelaDistinguibilidade(t *testing.T) {
+	// Cadeias que terminam em "ab", com q3 equivalente a q0
+	dfa := AutomatoFinito{
+		Estados:  []string{"q0", "q1", "q2", "q3"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"q0": {'a': {"q1"}, 'b': {"q3"}},
+			"q1": {'a': {"q1"}, 'b': {"q2"}},
+			"q2": {'a': {"q1"}, 'b': {"q0"}},
+			"q3": {'a': {"q1"}, 'b': {"q3"}},
+		},
+		EstadoInicial: "q0",
+		EstadosFinais: []string{"q2"},
+	}
+
+	tabela, err := dfa.tabelaDistinguibilidade()
+	if err != nil {
+		t.Fatalf("tabelaDistinguibilidade() erro inesperado: %v", err)
+	}
+
+	tests := []struct {
+		p, q           string
+		expectedSufixo string
+		expected       bool
+	}{
+		{"q0", "q2", "", true},
+		{"q1", "q2", "", true},
+		{"q0", "q1", "b", true},
+		{"q1", "q3", "b", true},
+		{"q0", "q3", "", false},
+		{"q3", "q0", "", false},
+	}
+	for _, tt := range tests {
+		sufixo, ok := tabela.distinguiveis(tt.p, tt.q)
+		if ok != tt.expected || sufixo != tt.expectedSufixo {
+			t.Errorf("distinguiveis(%s, %s) = (%q, %v), want (%q, %v)", tt.p, tt.q, sufixo, ok, tt.expectedSufixo, tt.expected)
+		}
+	}
+
+	expected := "" +
+		"q1 | b\n" +
+		"q2 | ε  ε\n" +
+		"q3 | ≡  b  ε\n" +
+		"   | q0 q1 q2\n"
+	if got := tabela.String(); got != expected {
+		t.Errorf("String() =\n%s\nwant\n%s", got, expected)
+	}
+}
+
+func TestTabelaDistinguibilidadeEstadoMorto(t *testing.T) {
+	// Aceita apenas "ab"; transições ausentes vão para o estado morto implícito
+	dfa := AutomatoFinito{
+		Estados:  []string{"q0", "q1", "q2"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"q0": {'a': {"q1"}},
+			"q1": {'b': {"q2"}},
+		},
+		EstadoInicial: "q0",
+		EstadosFinais: []string{"q2"},
+	}
+
+	tabela, err := dfa.tabelaDistinguibilidade()
+	if err != nil {
+		t.Fatalf("tabelaDistinguibilidade() erro inesperado: %v", err)
+	}
+	if sufixo, ok := tabela.distinguiveis("q0", "q1"); !ok || sufixo != "b" {
+		t.Errorf("distinguiveis(q0, q1) = (%q, %v), want (\"b\", true)", sufixo, ok)
+	}
+
+	nfa := AutomatoFinito{
+		Estados:           []string{"q0"},
+		TransicoesEpsilon: map[string][]string{"q0": {"q0"}},
+		EstadoInicial:     "q0",
+	}
+	if _, err := nfa.tabelaDistinguibilidade(); err == nil {
+		t.Errorf("tabelaDistinguibilidade() em AFN deveria retornar erro")
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// nomeNovoEstado retorna base, ou base seguido de um número, de forma que o nome não esteja em uso.
+func (AF *Automato[S]) nomeNovoEstado(base string) string {
+	emUso := func(nome string) bool {
+		if slices.Contains(AF.Estados, nome) {
+			return true
+		}
+		_, ok := AF.Transicoes[nome]
+		_, okEpsilon := AF.TransicoesEpsilon[nome]
+		return ok || okEpsilon
+	}
+	nome := base
+	for i := 1; emUso(nome); i++ {
+		nome = base + strconv.Itoa(i)
+	}
+	return nome
+}
+
+// reverso retorna um AFN que aceita o reverso da linguagem de AF: as transições são invertidas, o
+// estado inicial passa a ser o único final e os finais passam a ser iniciais (por meio de um novo
+// estado inicial com transições épsilon quando há mais de um).
+func (AF *Automato[S]) reverso() Automato[S] {
+	resultado := Automato[S]{
+		Estados:  slices.Clone(AF.Estados),
+		Alfabeto: slices.Clone(AF.Alfabeto),
+	}
+
+	comparar := comparadorSimbolos[S]()
+	for _, origem := range chavesOrdenadas(AF.Transicoes) {
+		m := AF.Transicoes[origem]
+		for _, simbolo := range slices.SortedFunc(maps.Keys(m), comparar) {
+			for _, destino := range m[simbolo] {
+				resultado.adicionarTransicao(destino, simbolo, origem)
+			}
+		}
+	}
+	for _, origem := range chavesOrdenadas(AF.TransicoesEpsilon) {
+		for _, destino := range AF.TransicoesEpsilon[origem] {
+			resultado.adicionarTransicaoEpsilon(destino, origem)
+		}
+	}
+	resultado.adicionarEstadoFinal(AF.EstadoInicial)
+
+	if len(AF.EstadosFinais) == 1 {
+		resultado.adicionarEstadoInicial(AF.EstadosFinais[0])
+		return resultado
+	}
+	novoInicial := AF.nomeNovoEstado("qi")
+	resultado.adicionarEstado(novoInicial)
+	resultado.adicionarEstadoInicial(novoInicial)
+	for _, final := range AF.EstadosFinais {
+		resultado.adicionarTransicaoEpsilon(novoInicial, final)
+	}
+	return resultado
+}
+
+// estadoMortoProduto representa, no produto, um lado que já não tem transição (AFD parcial).
+const estadoMortoProduto = "∅"
+
+// produto constrói o AFD produto dos dois autômatos (determinizados antes, se preciso). Um par
+// (p,q) é final quando aceita(p final, q final). Só os pares alcançáveis são gerados e o par em
+// que os dois lados estão mortos é omitido, por isso aceita(false, false) deve ser false.
+func produto[S comparable](A, B *Automato[S], aceita func(finalA, finalB bool) bool) Automato[S] {
+	AFDA, AFDB := A.determinizar(), B.determinizar()
+	simbolos := append(AFDA.simbolosOrdenados(), AFDB.simbolosOrdenados()...)
+	ordenarSimbolos(simbolos)
+	simbolos = slices.Compact(simbolos)
+
+	type par struct {
+		a, b string
+	}
+	nome := func(p par) string {
+		return "(" + p.a + "," + p.b + ")"
+	}
+	proximo := func(AFD *Automato[S], estado string, simbolo S) string {
+		if destinos := AFD.Transicoes[estado][simbolo]; len(destinos) > 0 {
+			return destinos[0]
+		}
+		return estadoMortoProduto
+	}
+
+	resultado := Automato[S]{Alfabeto: simbolos}
+	inicial := par{AFDA.EstadoInicial, AFDB.EstadoInicial}
+	resultado.adicionarEstado(nome(inicial))
+	resultado.adicionarEstadoInicial(nome(inicial))
+	fila := []par{inicial}
+	for len(fila) > 0 {
+		atual := fila[0]
+		fila = fila[1:]
+		if aceita(slices.Contains(AFDA.EstadosFinais, atual.a), slices.Contains(AFDB.EstadosFinais, atual.b)) {
+			resultado.adicionarEstadoFinal(nome(atual))
+		}
+		for _, simbolo := range simbolos {
+			seguinte := par{proximo(&AFDA, atual.a, simbolo), proximo(&AFDB, atual.b, simbolo)}
+			if seguinte.a == estadoMortoProduto && seguinte.b == estadoMortoProduto {
+				continue
+			}
+			if !slices.Contains(resultado.Estados, nome(seguinte)) {
+				resultado.adicionarEstado(nome(seguinte))
+				fila = append(fila, seguinte)
+			}
+			resultado.adicionarTransicao(nome(atual), simbolo, nome(seguinte))
+		}
+	}
+	return resultado
+}
+
+// primeirasAceitas retorna até k cadeias aceitas por um AFD, em ordem de tamanho e depois
+// alfabética. A busca só segue prefixos que ainda alcançam um estado final, então termina mesmo
+// quando a linguagem tem menos de k cadeias.
+func (AFD *Automato[S]) primeirasAceitas(k int) []string {
+	co := AFD.coAlcancaveis()
+	simbolos := AFD.simbolosOrdenados()
+	type item struct {
+		entrada []S
+		estado  string
+	}
+	var aceitas []string
+	fila := []item{{nil, AFD.EstadoInicial}}
+	if !co[AFD.EstadoInicial] {
+		return nil
+	}
+	for len(fila) > 0 && len(aceitas) < k {
+		atual := fila[0]
+		fila = fila[1:]
+		if slices.Contains(AFD.EstadosFinais, atual.estado) {
+			aceitas = append(aceitas, formatarEntrada(atual.entrada))
+		}
+		for _, simbolo := range simbolos {
+			for _, destino := range AFD.Transicoes[atual.estado][simbolo] {
+				if co[destino] {
+					fila = append(fila, item{append(slices.Clip(atual.entrada), simbolo), destino})
+				}
+			}
+		}
+	}
+	return aceitas
+}
+
+// uniao, intersecao e diferenca retornam o AFD produto com os estados renomeados para q0, q1, ...
+func uniao[S comparable](A, B *Automato[S]) Automato[S] {
+	return produtoCanonico(A, B, func(a, b bool) bool { return a || b })
+}
+
+func intersecao[S comparable](A, B *Automato[S]) Automato[S] {
+	return produtoCanonico(A, B, func(a, b bool) bool { return a && b })
+}
+
+func diferenca[S comparable](A, B *Automato[S]) Automato[S] {
+	return produtoCanonico(A, B, func(a, b bool) bool { return a && !b })
+}
+
+func produtoCanonico[S comparable](A, B *Automato[S], aceita func(finalA, finalB bool) bool) Automato[S] {
+	AFD := produto(A, B, aceita)
+	canonico, _, err := AFD.renomearCanonico()
+	if err != nil {
+		// O produto é sempre determinístico
+		panic(err)
+	}
+	return canonico
+}
+
+// equivalentes diz se os dois autômatos aceitam a mesma linguagem. Se não aceitam, retorna também a
+// menor cadeia aceita por apenas um deles.
+func equivalentes[S comparable](A, B *Automato[S]) (bool, string) {
+	diferentes := produto(A, B, func(a, b bool) bool { return a != b })
+	if contraexemplo := diferentes.primeirasAceitas(1); len(contraexemplo) > 0 {
+		return false, contraexemplo[0]
+	}
+	return true, ""
+}
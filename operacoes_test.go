@@ -0,0 +1,75 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestReverso(t *testing.T) {
+	// Começa com "ab"; o reverso termina com "ba"
+	AF := AutomatoFinito{
+		Estados:  []string{"q0", "q1", "q2"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"q0": {'a': {"q1"}},
+			"q1": {'b': {"q2"}},
+			"q2": {'a': {"q2"}, 'b': {"q2"}},
+		},
+		EstadoInicial: "q0",
+		EstadosFinais: []string{"q2"},
+	}
+	reverso := AF.reverso()
+	for _, tt := range []struct {
+		cadeia   string
+		expected bool
+	}{{"ba", true}, {"aba", true}, {"ab", false}, {"", false}} {
+		reverso.adicionarCadeia(tt.cadeia)
+		if got := reverso.funcionamento(); got != tt.expected {
+			t.Errorf("reverso com cadeia \"%s\": got %v, want %v", tt.cadeia, got, tt.expected)
+		}
+	}
+}
+
+func TestProdutoPrimeirasAceitas(t *testing.T) {
+	// Número par de 'a's
+	par := AutomatoFinito{
+		Estados:  []string{"p", "i"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"p": {'a': {"i"}, 'b': {"p"}},
+			"i": {'a': {"p"}, 'b': {"i"}},
+		},
+		EstadoInicial: "p",
+		EstadosFinais: []string{"p"},
+	}
+	// Termina com 'b' (parcial: não há transição a partir de f)
+	terminaB := AutomatoFinito{
+		Estados:  []string{"s", "f"},
+		Alfabeto: []rune{'a', 'b'},
+		Transicoes: map[string]map[rune][]string{
+			"s": {'a': {"s"}, 'b': {"s", "f"}},
+		},
+		EstadoInicial: "s",
+		EstadosFinais: []string{"f"},
+	}
+
+	intersecao := produto(&par, &terminaB, func(a, b bool) bool { return a && b })
+	if !intersecao.ehDeterministico() {
+		t.Fatalf("produto() deveria ser determinístico")
+	}
+	expected := []string{"b", "bb", "aab", "bbb", "aabb"}
+	if got := intersecao.primeirasAceitas(5); !slices.Equal(got, expected) {
+		t.Errorf("primeirasAceitas(5) = %q, want %q", got, expected)
+	}
+
+	// Linguagem finita com menos cadeias do que o pedido
+	soAB := AutomatoFinito{
+		Estados:       []string{"q0", "q1", "q2"},
+		Transicoes:    map[string]map[rune][]string{"q0": {'a': {"q1"}}, "q1": {'b': {"q2"}}},
+		EstadoInicial: "q0",
+		EstadosFinais: []string{"q2"},
+	}
+	if got := soAB.primeirasAceitas(10); !slices.Equal(got, []string{"ab"}) {
+		t.Errorf("primeirasAceitas(10) = %q, want [\"ab\"]", got)
+	}
+}
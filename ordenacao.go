@@ -0,0 +1,66 @@
+package main
+
+import (
+	"slices"
+	"strings"
+)
+
+// Toda saída que percorre um mapa (JSON, gramática, nomes gerados por algoritmos) usa a ordem
+// natural dos nomes de estados, para que a mesma entrada produza sempre o mesmo texto. Listas que
+// já têm ordem própria, como Estados e Alfabeto, são impressas na ordem de inserção.
+
+// compararNatural compara nomes de estados em ordem natural: trechos de dígitos são comparados
+// pelo valor numérico, de forma que q2 vem antes de q10. Nomes que só diferem em zeros à esquerda
+// (q01 e q1) são desempatados pela comparação comum de cadeias.
+func compararNatural(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if !ehDigito(a[i]) || !ehDigito(b[j]) {
+			if a[i] != b[j] {
+				return int(a[i]) - int(b[j])
+			}
+			i++
+			j++
+			continue
+		}
+		fimA, fimB := i, j
+		for fimA < len(a) && ehDigito(a[fimA]) {
+			fimA++
+		}
+		for fimB < len(b) && ehDigito(b[fimB]) {
+			fimB++
+		}
+		numeroA := strings.TrimLeft(a[i:fimA], "0")
+		numeroB := strings.TrimLeft(b[j:fimB], "0")
+		if len(numeroA) != len(numeroB) {
+			return len(numeroA) - len(numeroB)
+		}
+		if c := strings.Compare(numeroA, numeroB); c != 0 {
+			return c
+		}
+		i, j = fimA, fimB
+	}
+	if c := (len(a) - i) - (len(b) - j); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+func ehDigito(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// ordenarEstados ordena os nomes em ordem natural, no próprio slice.
+func ordenarEstados(estados []string) {
+	slices.SortFunc(estados, compararNatural)
+}
+
+// chavesOrdenadas retorna as chaves de um mapa indexado por estado, em ordem natural.
+func chavesOrdenadas[V any](m map[string]V) []string {
+	chaves := make([]string, 0, len(m))
+	for chave := range m {
+		chaves = append(chaves, chave)
+	}
+	ordenarEstados(chaves)
+	return chaves
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompararNatural(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int // sinal esperado
+	}{
+		{"q2", "q10", -1},
+		{"q10", "q2", 1},
+		{"q2", "q2", 0},
+		{"q", "q0", -1},
+		{"a9b", "a10a", -1},
+		{"q1a", "q1b", -1},
+		{"q01", "q1", -1},
+		{"{q2,q10}", "{q2,q9}", 1},
+		{"B", "a", -1},
+	}
+	for _, tt := range tests {
+		got := compararNatural(tt.a, tt.b)
+		if (got < 0 && tt.expected >= 0) || (got > 0 && tt.expected <= 0) || (got == 0 && tt.expected != 0) {
+			t.Errorf("compararNatural(%q, %q) = %d, want sinal %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+
+	estados := []string{"q10", "q1", "s", "q2", "q0", "q11"}
+	ordenarEstados(estados)
+	if expected := []string{"q0", "q1", "q2", "q10", "q11", "s"}; !reflect.DeepEqual(estados, expected) {
+		t.Errorf("ordenarEstados() = %v, want %v", estados, expected)
+	}
+}
+
+func TestSaidaEmOrdemNatural(t *testing.T) {
+	AF := AutomatoFinito{
+		Estados:  []string{"q0", "q2", "q10"},
+		Alfabeto: []rune{'a'},
+		Transicoes: map[string]map[rune][]string{
+			"q0":  {'a': {"q2", "q10"}},
+			"q10": {'a': {"q10"}},
+			"q2":  {'a': {"q2"}},
+		},
+		EstadoInicial: "q0",
+		EstadosFinais: []string{"q10"},
+	}
+
+	AFD := AF.determinizar()
+	if expected := []string{"{q0}", "{q2,q10}"}; !reflect.DeepEqual(AFD.Estados, expected) {
+		t.Errorf("determinizar() Estados = %v, want %v", AFD.Estados, expected)
+	}
+
+	primeiro, err := paraJSON(&AF)
+	if err != nil {
+		t.Fatalf("paraJSON() erro inesperado: %v", err)
+	}
+	texto := string(primeiro)
+	if i, j := strings.Index(texto, `"origem": "q2"`), strings.Index(texto, `"origem": "q10"`); i < 0 || j < 0 || i > j {
+		t.Errorf("paraJSON() deveria listar q2 antes de q10:\n%s", texto)
+	}
+	// A saída não pode depender da ordem de iteração dos mapas
+	for range 20 {
+		if outro, _ := paraJSON(&AF); string(outro) != texto {
+			t.Fatalf("paraJSON() mudou entre chamadas:\n%s\n---\n%s", texto, outro)
+		}
+		if gramatica := paraGramatica(&AF).String(); gramatica != paraGramatica(&AF).String() {
+			t.Fatalf("paraGramatica() mudou entre chamadas")
+		}
+	}
+}
@@ -0,0 +1,534 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ajudaComando descreve um comando do console para help e para as mensagens de uso.
+type ajudaComando struct {
+	Nome      string
+	Uso       string
+	Descricao string
+}
+
+var ajudaConsole = []ajudaComando{
+	{"state", "state NOME", "adiciona um estado"},
+	{"delstate", "delstate NOME", "remove o estado e suas transições"},
+	{"rename", "rename NOME NOVO", "renomeia um estado"},
+	{"symbol", "symbol S", "adiciona o símbolo S ao alfabeto"},
+	{"trans", "trans ORIGEM S DESTINO", "adiciona a transição (S = ε, eps ou epsilon para épsilon; \\ε para o símbolo ε)"},
+	{"deltrans", "deltrans ORIGEM S DESTINO", "remove a transição"},
+	{"start", "start NOME", "define o estado inicial"},
+	{"final", "final NOME", "marca o estado como final"},
+	{"nonfinal", "nonfinal NOME", "desmarca o estado como final"},
+	{"test", "test CADEIA", "testa a cadeia (sem argumento, a cadeia vazia)"},
+	{"show", "show", "exibe a tabela de transições"},
+	{"print", "print", "exibe o autômato no formato texto (.af)"},
+	{"grammar", "grammar", "exibe uma gramática linear à direita equivalente"},
+	{"classes", "classes [ARQUIVO [CADEIA]]", "exibe o AFD mínimo com as transições agrupadas em classes de símbolos; com um arquivo .afc, o do autômato do arquivo, ou testa CADEIA nele"},
+	{"sample", "sample N [QTD] [SEMENTE]", "sorteia cadeias aceitas de tamanho N, com probabilidade uniforme"},
+	{"buchi", "buchi [PREFIXO CICLO]", "lê o autômato como de Büchi: testa PREFIXO(CICLO)^ω ou, sem argumentos, diz se a linguagem é vazia"},
+	{"save", "save ARQUIVO", "salva o autômato atual (.af texto, .afc texto com classes, .csv ou .md tabela, .gr gramática, senão JSON)"},
+	{"load", "load ARQUIVO", "carrega um arquivo .af, .csv, .gr ou JSON no autômato atual, iniciando um novo histórico"},
+	{"undo", "undo", "desfaz o último comando"},
+	{"redo", "redo", "refaz o último comando desfeito"},
+	{"history", "history", "exibe o histórico como script"},
+	{"new", "new NOME", "cria um autômato vazio e passa a usá-lo"},
+	{"use", "use NOME", "passa a usar outro autômato da área de trabalho"},
+	{"list", "list", "lista os autômatos da área de trabalho"},
+	{"copy", "copy ORIGEM DESTINO", "copia um autômato com outro nome"},
+	{"delete", "delete NOME", "remove um autômato da área de trabalho"},
+	{"union", "union A B DESTINO", "guarda em DESTINO o AFD da união de A e B"},
+	{"intersect", "intersect A B DESTINO", "guarda em DESTINO o AFD da interseção de A e B"},
+	{"diff", "diff A B DESTINO", "guarda em DESTINO o AFD de A menos B"},
+	{"equiv", "equiv A B", "diz se A e B são equivalentes, com um contraexemplo se não forem"},
+	{"iso", "iso A B", "diz se os AFDs A e B são isomorfos, com a correspondência entre os estados"},
+	{"help", "help [COMANDO]", "exibe esta ajuda"},
+	{"quit", "quit", "sai do console"},
+	{"exit", "exit", "sai do console (o mesmo que quit)"},
+}
+
+func buscarAjuda(nome string) (ajudaComando, bool) {
+	i := slices.IndexFunc(ajudaConsole, func(a ajudaComando) bool { return a.Nome == nome })
+	if i < 0 {
+		return ajudaComando{}, false
+	}
+	return ajudaConsole[i], true
+}
+
+// distanciaEdicao é a distância de Levenshtein entre a e b, usada para sugerir comandos.
+func distanciaEdicao(a, b string) int {
+	x, y := []rune(a), []rune(b)
+	anterior := make([]int, len(y)+1)
+	for j := range anterior {
+		anterior[j] = j
+	}
+	for i := 1; i <= len(x); i++ {
+		atual := make([]int, len(y)+1)
+		atual[0] = i
+		for j := 1; j <= len(y); j++ {
+			custo := 1
+			if x[i-1] == y[j-1] {
+				custo = 0
+			}
+			atual[j] = min(anterior[j]+1, atual[j-1]+1, anterior[j-1]+custo)
+		}
+		anterior = atual
+	}
+	return anterior[len(y)]
+}
+
+// sugerirComando retorna o comando mais parecido com nome, se houver algum próximo o suficiente.
+func sugerirComando(nome string) (string, bool) {
+	melhor, menor := "", 3
+	for _, ajuda := range ajudaConsole {
+		if d := distanciaEdicao(nome, ajuda.Nome); d < menor {
+			melhor, menor = ajuda.Nome, d
+		}
+	}
+	return melhor, melhor != ""
+}
+
+// Console é o REPL de construção de autômatos. Cada linha é um comando; as mutações passam pela
+// sessão do autômato atual da área de trabalho, então podem ser desfeitas.
+type Console struct {
+	area *AreaTrabalho
+	sair bool
+}
+
+func novoConsole(area *AreaTrabalho) *Console {
+	return &Console{area: area}
+}
+
+// argumentosWorkspace são os comandos da área de trabalho e quantos nomes cada um recebe.
+var argumentosWorkspace = map[string]int{
+	"new": 1, "use": 1, "copy": 2, "delete": 1, "union": 3, "intersect": 3, "diff": 3, "equiv": 2, "iso": 2,
+}
+
+// executarWorkspace trata os comandos que operam sobre os autômatos guardados por nome.
+func (C *Console) executarWorkspace(linha string) (string, error) {
+	campos := strings.Fields(linha)
+	nome := campos[0]
+	if len(campos)-1 != argumentosWorkspace[nome] {
+		ajuda, _ := buscarAjuda(nome)
+		return "", fmt.Errorf("%s espera %d argumento(s), recebeu %d (uso: %s)", nome, argumentosWorkspace[nome], len(campos)-1, ajuda.Uso)
+	}
+	var args []string
+	for _, campo := range campos[1:] {
+		arg, err := decodificarCadeia(campo)
+		if err != nil {
+			return "", err
+		}
+		args = append(args, arg)
+	}
+
+	switch nome {
+	case "new":
+		if err := C.area.adicionar(args[0], AutomatoFinito{}); err != nil {
+			return "", err
+		}
+		C.area.atual = args[0]
+		return fmt.Sprintf("Usando o autômato '%s'.\n", args[0]), nil
+	case "use":
+		if err := C.area.usar(args[0]); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Usando o autômato '%s'.\n", args[0]), nil
+	case "copy":
+		return "", C.area.copiar(args[0], args[1])
+	case "delete":
+		return "", C.area.remover(args[0])
+	case "union", "intersect", "diff":
+		operacao := map[string]func(a, b *AutomatoFinito) AutomatoFinito{"union": uniao, "intersect": intersecao, "diff": diferenca}[nome]
+		if err := C.area.operar(operacao, args[0], args[1], args[2]); err != nil {
+			return "", err
+		}
+		resultado := C.area.sessoes[args[2]].Automato
+		return fmt.Sprintf("'%s' criado com %d estados.\n", args[2], len(resultado.Estados)), nil
+	case "equiv":
+		a, err := C.area.buscar(args[0])
+		if err != nil {
+			return "", err
+		}
+		b, err := C.area.buscar(args[1])
+		if err != nil {
+			return "", err
+		}
+		if ok, contraexemplo := equivalentes(&a.Automato, &b.Automato); !ok {
+			return fmt.Sprintf("Não equivalentes: %s é aceita por apenas um deles.\n", formatarCadeia(contraexemplo)), nil
+		}
+		return "Equivalentes.\n", nil
+	case "iso":
+		a, err := C.area.buscar(args[0])
+		if err != nil {
+			return "", err
+		}
+		b, err := C.area.buscar(args[1])
+		if err != nil {
+			return "", err
+		}
+		bijecao, ok, err := a.Automato.isomorfismo(&b.Automato)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return "Não isomorfos.\n", nil
+		}
+		var pares []string
+		for _, estado := range a.Automato.Estados {
+			if correspondente, ok := bijecao[estado]; ok {
+				pares = append(pares, codificarCadeia(estado)+" → "+codificarCadeia(correspondente))
+			}
+		}
+		return fmt.Sprintf("Isomorfos: %s\n", strings.Join(pares, ", ")), nil
+	}
+	return "", nil
+}
+
+// executarLinha interpreta uma linha do console e retorna o que deve ser exibido.
+func (C *Console) executarLinha(linha string) (string, error) {
+	linha = strings.TrimSpace(linha)
+	if linha == "" || strings.HasPrefix(linha, "#") {
+		return "", nil
+	}
+	nome, resto, _ := strings.Cut(linha, " ")
+	resto = strings.TrimSpace(resto)
+	sessao := C.area.sessaoAtual()
+	AF := &sessao.Automato
+
+	if _, ok := aridadeComandos[nome]; ok {
+		comando, err := lerComando(linha)
+		if err != nil {
+			ajuda, _ := buscarAjuda(nome)
+			return "", fmt.Errorf("%v (uso: %s)", err, ajuda.Uso)
+		}
+		return "", sessao.executar(comando)
+	}
+	if _, ok := argumentosWorkspace[nome]; ok {
+		return C.executarWorkspace(linha)
+	}
+
+	switch nome {
+	case "test":
+		if AF.EstadoInicial == "" {
+			return "", fmt.Errorf("defina o estado inicial com \"start NOME\" antes de testar")
+		}
+		cadeia, err := decodificarCadeia(resto)
+		if err != nil {
+			return "", err
+		}
+		if err := AF.adicionarCadeia(cadeia); err != nil {
+			return "", err
+		}
+		if AF.funcionamento() {
+			return formatarCadeia(cadeia) + ": aceita\n", nil
+		}
+		return formatarCadeia(cadeia) + ": não aceita\n", nil
+	case "show":
+		return formatarAutomato(AF)
+	case "print":
+		return paraDSL(AF)
+	case "grammar":
+		return paraGramatica(AF).String(), nil
+	case "classes":
+		return classesConsole(AF, strings.Fields(resto))
+	case "sample":
+		return amostrarConsole(AF, strings.Fields(resto))
+	case "buchi":
+		return buchiConsole(AF, strings.Fields(resto))
+	case "save", "load":
+		if resto == "" {
+			ajuda, _ := buscarAjuda(nome)
+			return "", fmt.Errorf("informe o arquivo (uso: %s)", ajuda.Uso)
+		}
+		caminho, err := decodificarCadeia(resto)
+		if err != nil {
+			return "", err
+		}
+		if nome == "save" {
+			if err := salvarAutomato(caminho, AF); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Autômato salvo em %s.\n", caminho), nil
+		}
+		carregado, err := carregarAutomato(caminho)
+		if err != nil {
+			return "", err
+		}
+		C.area.sessoes[C.area.atual] = novaSessao(carregado)
+		return fmt.Sprintf("Autômato carregado de %s (o histórico anterior foi descartado).\n", caminho), nil
+	case "undo":
+		comando, err := sessao.desfazer()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Desfeito: %s\n", comando), nil
+	case "redo":
+		comando, err := sessao.refazer()
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Refeito: %s\n", comando), nil
+	case "history":
+		return sessao.script(), nil
+	case "list":
+		var sb strings.Builder
+		for _, nome := range C.area.nomes() {
+			marcador := " "
+			if nome == C.area.atual {
+				marcador = "*"
+			}
+			AFNome := C.area.sessoes[nome].Automato
+			fmt.Fprintf(&sb, "%s %s (%d estados, %d símbolos)\n", marcador, codificarCadeia(nome), len(AFNome.Estados), len(AFNome.Alfabeto))
+		}
+		return sb.String(), nil
+	case "help":
+		return ajudaTexto(resto)
+	case "quit", "exit":
+		C.sair = true
+		return "", nil
+	}
+
+	if sugestao, ok := sugerirComando(nome); ok {
+		return "", fmt.Errorf("comando desconhecido %q; você quis dizer %q? Digite \"help\" para ver os comandos", nome, sugestao)
+	}
+	return "", fmt.Errorf("comando desconhecido %q. Digite \"help\" para ver os comandos", nome)
+}
+
+// maximoAmostrasConsole limita a quantidade de cadeias que um único "sample" pode sortear.
+const maximoAmostrasConsole = 1000
+
+// amostrarConsole trata "sample N [QUANTIDADE] [SEMENTE]". Sem semente, usa o relógio e a exibe, para
+// que a amostra possa ser repetida.
+func amostrarConsole(AF *AutomatoFinito, args []string) (string, error) {
+	ajuda, _ := buscarAjuda("sample")
+	if len(args) == 0 || len(args) > 3 {
+		return "", fmt.Errorf("uso: %s", ajuda.Uso)
+	}
+	numeros := []int64{0, 1, time.Now().UnixNano()}
+	for i, arg := range args {
+		numero, err := strconv.ParseInt(arg, 10, 64)
+		if err != nil || (i < 2 && numero < 0) {
+			return "", fmt.Errorf("%q não é um número válido (uso: %s)", arg, ajuda.Uso)
+		}
+		numeros[i] = numero
+	}
+	tamanho, quantidade, semente := int(numeros[0]), int(numeros[1]), numeros[2]
+	if quantidade > maximoAmostrasConsole {
+		return "", fmt.Errorf("no máximo %d cadeias por sorteio", maximoAmostrasConsole)
+	}
+	if AF.EstadoInicial == "" {
+		return "", fmt.Errorf("defina o estado inicial com \"start NOME\" antes de sortear")
+	}
+
+	amostras, total, err := AF.amostrarUniformes(tamanho, quantidade, rand.New(rand.NewSource(semente)))
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s cadeia(s) de tamanho %d aceita(s); semente %d:\n", total, tamanho, semente)
+	for _, cadeia := range amostras {
+		fmt.Fprintf(&sb, "  %s\n", formatarCadeia(cadeia))
+	}
+	return sb.String(), nil
+}
+
+// classesConsole trata "classes [ARQUIVO [CADEIA]]". Sem argumentos, usa o autômato atual; com um
+// arquivo .afc, o autômato com classes lido dele, que não cabe na área de trabalho.
+func classesConsole(AF *AutomatoFinito, args []string) (string, error) {
+	if len(args) == 0 {
+		AC := paraClasses(AF)
+		return AC.minimizar().String(), nil
+	}
+	if len(args) > 2 {
+		ajuda, _ := buscarAjuda("classes")
+		return "", fmt.Errorf("uso: %s", ajuda.Uso)
+	}
+	AC, err := carregarAutomatoClasses(args[0])
+	if err != nil {
+		return "", err
+	}
+	if len(args) == 1 {
+		return AC.minimizar().String(), nil
+	}
+	cadeia, err := decodificarCadeia(args[1])
+	if err != nil {
+		return "", err
+	}
+	if AC.aceita(cadeia) {
+		return formatarCadeia(cadeia) + ": aceita\n", nil
+	}
+	return formatarCadeia(cadeia) + ": não aceita\n", nil
+}
+
+// buchiConsole trata "buchi [PREFIXO CICLO]", com o autômato atual lido como autômato de Büchi.
+func buchiConsole(AF *AutomatoFinito, args []string) (string, error) {
+	if AF.EstadoInicial == "" {
+		return "", fmt.Errorf("defina o estado inicial com \"start NOME\" antes de testar")
+	}
+	B := AutomatoBuchi{*AF}
+	switch len(args) {
+	case 0:
+		vazio, laco, err := B.vazio()
+		if err != nil {
+			return "", err
+		}
+		if vazio {
+			return "Linguagem ω vazia.\n", nil
+		}
+		return fmt.Sprintf("Linguagem ω não vazia; aceita, por exemplo, %s.\n", laco), nil
+	case 2:
+		var partes [2]string
+		for i, arg := range args {
+			parte, err := decodificarCadeia(arg)
+			if err != nil {
+				return "", err
+			}
+			partes[i] = parte
+		}
+		if partes[1] == "" {
+			return "", fmt.Errorf("o ciclo não pode ser vazio")
+		}
+		laco := Laco{partes[0], partes[1]}
+		aceita, err := B.aceita(laco)
+		if err != nil {
+			return "", err
+		}
+		if aceita {
+			return fmt.Sprintf("%s: aceita\n", laco), nil
+		}
+		return fmt.Sprintf("%s: não aceita\n", laco), nil
+	}
+	ajuda, _ := buscarAjuda("buchi")
+	return "", fmt.Errorf("uso: %s", ajuda.Uso)
+}
+
+func ajudaTexto(nome string) (string, error) {
+	if nome != "" {
+		ajuda, ok := buscarAjuda(nome)
+		if !ok {
+			return "", fmt.Errorf("comando desconhecido %q", nome)
+		}
+		return fmt.Sprintf("%s\n    %s\n", ajuda.Uso, ajuda.Descricao), nil
+	}
+	var sb strings.Builder
+	sb.WriteString("Comandos:\n")
+	for _, ajuda := range ajudaConsole {
+		fmt.Fprintf(&sb, "  %-27s %s\n", ajuda.Uso, ajuda.Descricao)
+	}
+	sb.WriteString("Nomes e cadeias usam os escapes \\s (espaço), \\t, \\\\ e \\ε; Tab completa comandos, estados e símbolos.\n")
+	return sb.String(), nil
+}
+
+// argumentosCompletaveis indica, para cada comando, o que completar em cada posição.
+var argumentosCompletaveis = map[string][]string{
+	"state":     {""},
+	"delstate":  {"estado"},
+	"rename":    {"estado", ""},
+	"symbol":    {""},
+	"trans":     {"estado", "simbolo", "estado"},
+	"deltrans":  {"estado", "simbolo", "estado"},
+	"start":     {"estado"},
+	"final":     {"estado"},
+	"nonfinal":  {"estado"},
+	"help":      {"comando"},
+	"use":       {"automato"},
+	"copy":      {"automato", ""},
+	"delete":    {"automato"},
+	"union":     {"automato", "automato", ""},
+	"intersect": {"automato", "automato", ""},
+	"diff":      {"automato", "automato", ""},
+	"equiv":     {"automato", "automato"},
+	"iso":       {"automato", "automato"},
+}
+
+// completar completa a última palavra da linha. Retorna a linha completada e, quando há mais de uma
+// opção, as opções possíveis. Com uma única opção, a palavra é completada e seguida de um espaço;
+// com várias, é estendida até o maior prefixo comum.
+func completar(linha string, area *AreaTrabalho) (string, []string) {
+	AF := &area.sessaoAtual().Automato
+	campos := strings.Fields(linha)
+	if len(campos) == 0 || strings.HasSuffix(linha, " ") {
+		campos = append(campos, "")
+	}
+	palavra := campos[len(campos)-1]
+	posicao := len(campos) - 1
+
+	var candidatos []string
+	if posicao == 0 {
+		for _, ajuda := range ajudaConsole {
+			candidatos = append(candidatos, ajuda.Nome)
+		}
+	} else if tipos := argumentosCompletaveis[campos[0]]; posicao <= len(tipos) {
+		switch tipos[posicao-1] {
+		case "estado":
+			for _, estado := range AF.Estados {
+				candidatos = append(candidatos, codificarCadeia(estado))
+			}
+		case "simbolo":
+			for _, simbolo := range AF.Alfabeto {
+				candidatos = append(candidatos, codificarCadeia(string(simbolo)))
+			}
+			candidatos = append(candidatos, string(epsilonRune))
+		case "automato":
+			for _, nome := range area.nomes() {
+				candidatos = append(candidatos, codificarCadeia(nome))
+			}
+		case "comando":
+			for _, ajuda := range ajudaConsole {
+				candidatos = append(candidatos, ajuda.Nome)
+			}
+		}
+	}
+
+	var opcoes []string
+	for _, candidato := range candidatos {
+		if strings.HasPrefix(candidato, palavra) {
+			opcoes = append(opcoes, candidato)
+		}
+	}
+	inicio := linha[:len(linha)-len(palavra)]
+	switch len(opcoes) {
+	case 0:
+		return linha, nil
+	case 1:
+		return inicio + opcoes[0] + " ", nil
+	}
+	prefixo := opcoes[0]
+	for _, opcao := range opcoes[1:] {
+		for !strings.HasPrefix(opcao, prefixo) {
+			_, tamanho := utf8.DecodeLastRuneInString(prefixo)
+			prefixo = prefixo[:len(prefixo)-tamanho]
+		}
+	}
+	return inicio + prefixo, opcoes
+}
+
+// console é o laço interativo do REPL. No terminal, a linha é editada com completar por Tab.
+func console(area *AreaTrabalho) {
+	C := novoConsole(area)
+	fmt.Println("\n==== Console de autômatos ====")
+	fmt.Println("Digite \"help\" para ver os comandos e \"quit\" para voltar ao menu principal.")
+	fmt.Printf("Autômato atual: '%s'.\n", area.atual)
+	for !C.sair {
+		linha, ok := lerLinhaEditavel("af:"+codificarCadeia(C.area.atual)+"> ", func(linha string) (string, []string) {
+			return completar(linha, C.area)
+		})
+		if !ok {
+			fmt.Println()
+			return
+		}
+		saida, err := C.executarLinha(linha)
+		if err != nil {
+			fmt.Printf("Erro: %v.\n", err)
+			continue
+		}
+		fmt.Print(saida)
+	}
+}
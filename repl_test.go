@@ -0,0 +1,165 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestConsoleExecutarLinha(t *testing.T) {
+	C := novoConsole(novaAreaTrabalho())
+	caminho := filepath.Join(t.TempDir(), "termina_ab.json")
+	passos := []struct {
+		linha string
+		saida string
+		erro  string
+	}{
+		{"state q0", "", ""},
+		{"state q1", "", ""},
+		{"state q2", "", ""},
+		{"symbol a", "", ""},
+		{"symbol b", "", ""},
+		{"test ab", "", "defina o estado inicial"},
+		{"start q0", "", ""},
+		{"trans q0 a q0", "", ""},
+		{"trans q0 b q0", "", ""},
+		{"trans q0 a q1", "", ""},
+		{"trans q1 b q2", "", ""},
+		{"final q2", "", ""},
+		{"test ab", "\"ab\": aceita\n", ""},
+		{"test", "ε: não aceita\n", ""},
+		{"test ba", "\"ba\": não aceita\n", ""},
+		{"trans q1 c q2", "", "símbolo 'c' não está no alfabeto"},
+		{"trans q1 b", "", "uso: trans ORIGEM S DESTINO"},
+		{"stat q3", "", "você quis dizer \"state\"?"},
+		{"xyzzy", "", "comando desconhecido \"xyzzy\". Digite \"help\""},
+		{"undo", "Desfeito: final q2\n", ""},
+		{"test ab", "\"ab\": não aceita\n", ""},
+		{"redo", "Refeito: final q2\n", ""},
+		{"save " + caminho, "Autômato salvo em " + caminho + ".\n", ""},
+		{"delstate q2", "", ""},
+		{"load " + caminho, "Autômato carregado de " + caminho + " (o histórico anterior foi descartado).\n", ""},
+		{"test aab", "\"aab\": aceita\n", ""},
+		{"undo", "", "nada para desfazer"},
+		{"# comentário", "", ""},
+		{"help trans", "trans ORIGEM S DESTINO\n    adiciona a transição (S = ε, eps ou epsilon para épsilon; \\ε para o símbolo ε)\n", ""},
+	}
+	for _, passo := range passos {
+		saida, err := C.executarLinha(passo.linha)
+		if passo.erro == "" && err != nil {
+			t.Fatalf("executarLinha(%q) erro inesperado: %v", passo.linha, err)
+		}
+		if passo.erro != "" && (err == nil || !strings.Contains(err.Error(), passo.erro)) {
+			t.Fatalf("executarLinha(%q) erro = %v, want contendo %q", passo.linha, err, passo.erro)
+		}
+		if saida != passo.saida {
+			t.Fatalf("executarLinha(%q) = %q, want %q", passo.linha, saida, passo.saida)
+		}
+	}
+
+	if _, err := C.executarLinha("quit"); err != nil || !C.sair {
+		t.Errorf("quit deveria encerrar o console")
+	}
+	C.sair = false
+	if _, err := C.executarLinha("exit"); err != nil || !C.sair {
+		t.Errorf("exit deveria encerrar o console")
+	}
+}
+
+func TestConsoleAnalises(t *testing.T) {
+	C := novoConsole(novaAreaTrabalho())
+	for _, linha := range []string{
+		"state q0", "state q1", "state q2", "symbol a", "symbol b", "start q0", "final q2",
+		"trans q0 a q0", "trans q0 b q0", "trans q0 a q1", "trans q1 b q2",
+		// "infinitos b": lido como autômato de Büchi
+		"new omega", "state p", "state q", "symbol a", "symbol b", "start p", "final q",
+		"trans p a p", "trans p b q", "trans q b q", "trans q a p",
+		"use principal",
+	} {
+		if _, err := C.executarLinha(linha); err != nil {
+			t.Fatalf("executarLinha(%q) erro inesperado: %v", linha, err)
+		}
+	}
+	// Identificadores com letras acentuadas, escritos com intervalos num arquivo .afc
+	identificador := filepath.Join(t.TempDir(), "identificador.afc")
+	texto := "start q0\nfinal q1\nq0 -[a-zà-ÿ_]-> q1\nq1 -[a-zà-ÿ_\\d]-> q1\n"
+	if err := os.WriteFile(identificador, []byte(texto), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	passos := []struct {
+		linha string
+		saida string
+		erro  string
+	}{
+		{"grammar", "S -> aS | aA | bS\nA -> bB\nB -> ε\n", ""},
+		{"classes", "states {q0} {q0,q1} {q0,q2}\nstart {q0}\nfinal {q0,q2}\n{q0} -a-> {q0,q1}\n{q0} -b-> {q0}\n{q0,q1} -a-> {q0,q1}\n{q0,q1} -b-> {q0,q2}\n{q0,q2} -a-> {q0,q1}\n{q0,q2} -b-> {q0}\n", ""},
+		{"classes " + identificador, "states q0 q1\nstart q0\nfinal q1\nq0 -[_a-zà-ÿ]-> q1\nq1 -[0-9_a-zà-ÿ]-> q1\n", ""},
+		{"classes " + identificador + " ação_1", "\"ação_1\": aceita\n", ""},
+		{"classes " + identificador + " 1a", "\"1a\": não aceita\n", ""},
+		{"classes " + identificador + " a b", "", "uso: classes [ARQUIVO [CADEIA]]"},
+		{"classes nada.afc", "", "nada.afc"},
+		{"sample 3 2 7", "2 cadeia(s) de tamanho 3 aceita(s); semente 7:\n  \"aab\"\n  \"bab\"\n", ""},
+		{"sample 0", "", "nenhuma cadeia de tamanho 0"},
+		{"sample x", "", "\"x\" não é um número válido"},
+		{"sample 3 1001", "", "no máximo 1000 cadeias"},
+		{"sample 1 0 7", "0 cadeia(s) de tamanho 1 aceita(s); semente 7:\n", ""},
+		{"buchi", "Linguagem ω vazia.\n", ""},
+		{"use omega", "Usando o autômato 'omega'.\n", ""},
+		{"buchi", "Linguagem ω não vazia; aceita, por exemplo, b(ab)^ω.\n", ""},
+		{"buchi a ab", "a(ab)^ω: aceita\n", ""},
+		{"buchi b a", "b(a)^ω: não aceita\n", ""},
+		{"buchi a ε", "", "o ciclo não pode ser vazio"},
+		{"buchi a", "", "uso: buchi [PREFIXO CICLO]"},
+	}
+	for _, passo := range passos {
+		saida, err := C.executarLinha(passo.linha)
+		if passo.erro == "" && err != nil {
+			t.Fatalf("executarLinha(%q) erro inesperado: %v", passo.linha, err)
+		}
+		if passo.erro != "" && (err == nil || !strings.Contains(err.Error(), passo.erro)) {
+			t.Fatalf("executarLinha(%q) erro = %v, want contendo %q", passo.linha, err, passo.erro)
+		}
+		if saida != passo.saida {
+			t.Errorf("executarLinha(%q) = %q, want %q", passo.linha, saida, passo.saida)
+		}
+	}
+}
+
+func TestCompletar(t *testing.T) {
+	area := novaAreaTrabalho()
+	area.sessoes[nomeAutomatoPadrao] = novaSessao(AutomatoFinito{
+		Estados:  []string{"q0", "q1", "inicio", "q 2"},
+		Alfabeto: []rune{'a', 'b'},
+	})
+	area.adicionar("par", AutomatoFinito{})
+	tests := []struct {
+		linha    string
+		expected string
+		opcoes   []string
+	}{
+		{"st", "sta", []string{"state", "start"}},
+		{"sta", "sta", []string{"state", "start"}},
+		{"star", "start ", nil},
+		{"trans i", "trans inicio ", nil},
+		{"trans q", "trans q", []string{"q0", "q1", "q\\s2"}},
+		{"trans q0 ", "trans q0 ", []string{"a", "b", "ε"}},
+		{"trans q0 b q\\", "trans q0 b q\\s2 ", nil},
+		{"state q", "state q", nil},
+		{"trans q0 a q1 ", "trans q0 a q1 ", nil},
+		{"help del", "help del", []string{"delstate", "deltrans", "delete"}},
+		{"union p", "union p", []string{"par", "principal"}},
+		{"union par pri", "union par principal ", nil},
+		{"un", "un", []string{"undo", "union"}},
+		{"ex", "exit ", nil},
+		{"help ex", "help exit ", nil},
+	}
+	for _, tt := range tests {
+		got, opcoes := completar(tt.linha, area)
+		if got != tt.expected || !slices.Equal(opcoes, tt.opcoes) {
+			t.Errorf("completar(%q) = %q, %q, want %q, %q", tt.linha, got, opcoes, tt.expected, tt.opcoes)
+		}
+	}
+}
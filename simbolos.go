@@ -0,0 +1,82 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// comparadorSimbolos escolhe, uma vez por tipo de símbolo, a ordem dos símbolos: números e cadeias
+// pela ordem natural do tipo subjacente e os demais pela representação textual. Só tipos nomeados
+// (como um token definido sobre int) precisam de reflexão a cada comparação.
+func comparadorSimbolos[S comparable]() func(a, b S) int {
+	switch any(*new(S)).(type) {
+	case rune:
+		return ordemNatural[S, rune]
+	case byte:
+		return ordemNatural[S, byte]
+	case string:
+		return ordemNatural[S, string]
+	case int:
+		return ordemNatural[S, int]
+	case int64:
+		return ordemNatural[S, int64]
+	case uint:
+		return ordemNatural[S, uint]
+	case uint64:
+		return ordemNatural[S, uint64]
+	case float64:
+		return ordemNatural[S, float64]
+	}
+	switch reflect.TypeFor[S]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b S) int { return cmp.Compare(reflect.ValueOf(a).Int(), reflect.ValueOf(b).Int()) }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(a, b S) int { return cmp.Compare(reflect.ValueOf(a).Uint(), reflect.ValueOf(b).Uint()) }
+	case reflect.Float32, reflect.Float64:
+		return func(a, b S) int { return cmp.Compare(reflect.ValueOf(a).Float(), reflect.ValueOf(b).Float()) }
+	case reflect.String:
+		return func(a, b S) int { return strings.Compare(reflect.ValueOf(a).String(), reflect.ValueOf(b).String()) }
+	}
+	return func(a, b S) int { return strings.Compare(fmt.Sprint(a), fmt.Sprint(b)) }
+}
+
+// ordemNatural compara símbolos cujo tipo é exatamente T.
+func ordemNatural[S comparable, T cmp.Ordered](a, b S) int {
+	return cmp.Compare(any(a).(T), any(b).(T))
+}
+
+func ordenarSimbolos[S comparable](simbolos []S) {
+	slices.SortFunc(simbolos, comparadorSimbolos[S]())
+}
+
+// formatarSimbolo mostra runas como o próprio caractere e os demais símbolos com fmt.
+func formatarSimbolo[S comparable](simbolo S) string {
+	if r, ok := any(simbolo).(rune); ok {
+		return string(r)
+	}
+	return fmt.Sprint(simbolo)
+}
+
+// formatarEntrada junta os símbolos de uma entrada: runas formam a própria cadeia e os demais
+// símbolos são separados por espaços.
+func formatarEntrada[S comparable](entrada []S) string {
+	if runas, ok := any(entrada).([]rune); ok {
+		return string(runas)
+	}
+	partes := make([]string, len(entrada))
+	for i, simbolo := range entrada {
+		partes[i] = formatarSimbolo(simbolo)
+	}
+	return strings.Join(partes, " ")
+}
+
+// prefixarEntrada acrescenta um símbolo no início de uma entrada já formatada por formatarEntrada.
+func prefixarEntrada[S comparable](simbolo S, entrada string) string {
+	if _, ok := any(simbolo).(rune); ok || entrada == "" {
+		return formatarSimbolo(simbolo) + entrada
+	}
+	return formatarSimbolo(simbolo) + " " + entrada
+}
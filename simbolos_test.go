@@ -0,0 +1,146 @@
+package main
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// automatoComando aceita comandos "IF ID THEN ID" com um ELSE ID opcional no final.
+func automatoComando() Automato[string] {
+	AF := Automato[string]{}
+	for _, estado := range []string{"s0", "s1", "s2", "s3", "s4", "s5"} {
+		AF.adicionarEstado(estado)
+	}
+	for _, token := range []string{"IF", "ID", "THEN", "ELSE"} {
+		AF.adicionarAlfabeto(token)
+	}
+	AF.adicionarTransicao("s0", "IF", "s1")
+	AF.adicionarTransicao("s1", "ID", "s2")
+	AF.adicionarTransicao("s2", "THEN", "s3")
+	AF.adicionarTransicao("s3", "ID", "s4")
+	AF.adicionarTransicao("s4", "ELSE", "s5")
+	AF.adicionarTransicaoEpsilon("s5", "s3")
+	AF.adicionarEstadoInicial("s0")
+	AF.adicionarEstadoFinal("s4")
+	return AF
+}
+
+func TestAutomatoTokens(t *testing.T) {
+	AF := automatoComando()
+	tests := []struct {
+		entrada  string
+		expected bool
+	}{
+		{"IF ID THEN ID", true},
+		{"IF ID THEN ID ELSE ID", true},
+		{"IF  ID THEN\tID ELSE ID ELSE ID", true},
+		{"IF ID THEN", false},
+		{"IF ID ELSE ID", false},
+		{"", false},
+	}
+	AFD := AF.determinizar()
+	for _, tt := range tests {
+		AF.adicionarCadeia(tt.entrada)
+		if got := AF.funcionamento(); got != tt.expected {
+			t.Errorf("funcionamento(%q) = %v, want %v", tt.entrada, got, tt.expected)
+		}
+		AFD.adicionarCadeia(tt.entrada)
+		if got := AFD.funcionamento(); got != tt.expected {
+			t.Errorf("determinizar().funcionamento(%q) = %v, want %v", tt.entrada, got, tt.expected)
+		}
+	}
+
+	if !AFD.ehDeterministico() || AF.ehDeterministico() {
+		t.Errorf("ehDeterministico() incorreto para o AFN ou o AFD")
+	}
+	expected := []string{"IF ID THEN ID", "IF ID THEN ID ELSE ID"}
+	if got := AFD.primeirasAceitas(2); !reflect.DeepEqual(got, expected) {
+		t.Errorf("primeirasAceitas(2) = %q, want %q", got, expected)
+	}
+	if got := AF.simbolosOrdenados(); !slices.Equal(got, []string{"ELSE", "ID", "IF", "THEN"}) {
+		t.Errorf("simbolosOrdenados() = %q", got)
+	}
+}
+
+type token int
+
+const (
+	tokenNum token = iota
+	tokenMais
+	tokenVezes
+)
+
+func TestAutomatoSimbolosProprios(t *testing.T) {
+	// Expressões NUM ((MAIS|VEZES) NUM)*; o tipo token não tem épsilon
+	AF := Automato[token]{}
+	AF.adicionarEstado("e")
+	AF.adicionarEstado("op")
+	AF.adicionarTransicao("e", tokenNum, "op")
+	AF.adicionarTransicao("op", tokenVezes, "e")
+	AF.adicionarTransicao("op", tokenMais, "e")
+	AF.adicionarEstadoInicial("e")
+	AF.adicionarEstadoFinal("op")
+
+	AF.adicionarEntrada(tokenNum, tokenVezes, tokenNum)
+	if !AF.funcionamento() {
+		t.Errorf("funcionamento(NUM VEZES NUM) = false, want true")
+	}
+	AF.adicionarEntrada(tokenNum, tokenMais)
+	if AF.funcionamento() {
+		t.Errorf("funcionamento(NUM MAIS) = true, want false")
+	}
+	if got := AF.simbolosOrdenados(); !slices.Equal(got, []token{tokenNum, tokenMais, tokenVezes}) {
+		t.Errorf("simbolosOrdenados() = %v", got)
+	}
+	if err := AF.adicionarCadeia("0 2"); err == nil {
+		t.Errorf("adicionarCadeia() com símbolos token deveria falhar")
+	}
+	if _, err := AF.executarSuite([]CasoTeste{{Linha: 3, Cadeia: "0", Aceita: true}}); err == nil || !strings.Contains(err.Error(), "linha 3") {
+		t.Errorf("executarSuite() erro = %v, want erro na linha 3", err)
+	}
+
+	// Com dois finais, o reverso não pode usar épsilon
+	AF.adicionarEstadoFinal("e")
+	reverso := AF.reverso()
+	for _, entrada := range [][]token{{}, {tokenNum}, {tokenNum, tokenMais}, {tokenMais, tokenNum, tokenVezes, tokenNum}} {
+		reverso.adicionarEntrada(entrada...)
+		invertida := slices.Clone(entrada)
+		slices.Reverse(invertida)
+		AF.adicionarEntrada(invertida...)
+		if got, expected := reverso.funcionamento(), AF.funcionamento(); got != expected {
+			t.Errorf("reverso().funcionamento(%v) = %v, want %v", entrada, got, expected)
+		}
+	}
+	if ok, contraexemplo := equivalentes(&AF, &reverso); ok || contraexemplo != "0 1" {
+		t.Errorf("equivalentes() = %v, %q, want false, \"0 1\"", ok, contraexemplo)
+	}
+}
+
+func TestAutomatoBytes(t *testing.T) {
+	// Cadeias de bytes com o bit mais alto ligado em posições pares
+	AF := Automato[byte]{}
+	AF.adicionarEstado("par")
+	AF.adicionarEstado("impar")
+	for b := 0x80; b <= 0xFF; b++ {
+		AF.adicionarTransicao("par", byte(b), "impar")
+	}
+	for b := 0; b <= 0xFF; b++ {
+		AF.adicionarTransicao("impar", byte(b), "par")
+	}
+	AF.adicionarEstadoInicial("par")
+	AF.adicionarEstadoFinal("par")
+
+	AF.adicionarCadeia("é!") // 0xC3 0xA9 0x21 em UTF-8
+	if AF.funcionamento() {
+		t.Errorf("funcionamento(\"é!\") = true, want false")
+	}
+	AF.adicionarCadeia("éé")
+	if !AF.funcionamento() {
+		t.Errorf("funcionamento(\"éé\") = false, want true")
+	}
+	if got := AF.contarAceitas(2).Int64(); got != 128*256 {
+		t.Errorf("contarAceitas(2) = %d, want %d", got, 128*256)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CasoTeste é uma linha da suíte: uma cadeia e se o autômato deve aceitá-la.
+type CasoTeste struct {
+	Linha  int
+	Cadeia string
+	Aceita bool
+}
+
+// ResultadoSuite guarda os casos que falharam; um caso falho obteve o contrário de Aceita.
+type ResultadoSuite struct {
+	Total  int
+	Falhas []CasoTeste
+}
+
+// lerSuiteTestes lê uma suíte no formato
+//
+//	# comentário
+//	+ ab    (deve aceitar "ab")
+//	- ba    (deve rejeitar "ba")
+//	+ ε     (deve aceitar a cadeia vazia; "+" sozinho também)
+//
+// A cadeia passa por decodificarCadeia, então "\s" é um espaço e "\ε" é o símbolo ε.
+func lerSuiteTestes(texto string) ([]CasoTeste, error) {
+	var casos []CasoTeste
+	for i, linha := range strings.Split(texto, "\n") {
+		linha = strings.TrimSpace(linha)
+		if linha == "" || strings.HasPrefix(linha, "#") {
+			continue
+		}
+		caso := CasoTeste{Linha: i + 1}
+		switch linha[0] {
+		case '+':
+			caso.Aceita = true
+		case '-':
+			caso.Aceita = false
+		default:
+			return nil, fmt.Errorf("linha %d: esperado \"+\" ou \"-\" no início, encontrado %q", i+1, linha)
+		}
+		cadeia, err := decodificarCadeia(strings.TrimSpace(linha[1:]))
+		if err != nil {
+			return nil, fmt.Errorf("linha %d: %w", i+1, err)
+		}
+		caso.Cadeia = cadeia
+		casos = append(casos, caso)
+	}
+	return casos, nil
+}
+
+// executarSuite roda cada caso contra o autômato. A cadeia do autômato original não é alterada.
+// Os casos são texto, então só servem para símbolos que adicionarCadeia sabe ler.
+func (AF *Automato[S]) executarSuite(casos []CasoTeste) (ResultadoSuite, error) {
+	copia := *AF
+	resultado := ResultadoSuite{Total: len(casos)}
+	for _, caso := range casos {
+		if err := copia.adicionarCadeia(caso.Cadeia); err != nil {
+			return ResultadoSuite{}, fmt.Errorf("linha %d: %w", caso.Linha, err)
+		}
+		if copia.funcionamento() != caso.Aceita {
+			resultado.Falhas = append(resultado.Falhas, caso)
+		}
+	}
+	return resultado, nil
+}
+
+func (R ResultadoSuite) String() string {
+	descricao := map[bool]string{true: "aceita", false: "rejeita"}
+	var sb strings.Builder
+	for _, falha := range R.Falhas {
+		fmt.Fprintf(&sb, "FALHOU linha %d: %s\n", falha.Linha, formatarCadeia(falha.Cadeia))
+		fmt.Fprintf(&sb, "  esperado: %s\n", descricao[falha.Aceita])
+		fmt.Fprintf(&sb, "  obtido:   %s\n", descricao[!falha.Aceita])
+	}
+	fmt.Fprintf(&sb, "%d/%d casos passaram", R.Total-len(R.Falhas), R.Total)
+	if len(R.Falhas) > 0 {
+		fmt.Fprintf(&sb, ", %d falharam", len(R.Falhas))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
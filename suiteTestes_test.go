@@ -0,0 +1,91 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLerSuiteTestes(t *testing.T) {
+	texto := "# termina com ab\n+ ab\n\n- ba\n+\n- ε\n+ a\\sb\n- \\ε\n"
+	casos, err := lerSuiteTestes(texto)
+	if err != nil {
+		t.Fatalf("lerSuiteTestes() erro inesperado: %v", err)
+	}
+	expected := []CasoTeste{
+		{2, "ab", true},
+		{4, "ba", false},
+		{5, "", true},
+		{6, "", false},
+		{7, "a b", true},
+		{8, "ε", false},
+	}
+	if !reflect.DeepEqual(casos, expected) {
+		t.Errorf("lerSuiteTestes() = %+v, want %+v", casos, expected)
+	}
+
+	for _, tt := range []struct {
+		texto    string
+		expected string
+	}{
+		{"+ a\n* b", "linha 2"},
+		{"+ a\\", "barra invertida"},
+		{"- a\\u00g1", "quatro dígitos"},
+	} {
+		if _, err := lerSuiteTestes(tt.texto); err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("lerSuiteTestes(%q) erro = %v, want contendo %q", tt.texto, err, tt.expected)
+		}
+	}
+}
+
+func TestExecutarSuite(t *testing.T) {
+	casos, err := lerSuiteTestes("+ ab\n+ aab\n- ba\n+ ε\n- abb\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	AFN := referenciaTerminaAB
+	resultado, err := AFN.executarSuite(casos)
+	if err != nil || resultado.Total != 5 || len(resultado.Falhas) != 1 || resultado.Falhas[0].Linha != 4 {
+		t.Fatalf("executarSuite() = %+v, %v, want uma falha na linha 4", resultado, err)
+	}
+	expected := "FALHOU linha 4: ε\n  esperado: aceita\n  obtido:   rejeita\n4/5 casos passaram, 1 falharam\n"
+	if got := resultado.String(); got != expected {
+		t.Errorf("String() = %q, want %q", got, expected)
+	}
+}
+
+func TestComandoTestar(t *testing.T) {
+	diretorio := t.TempDir()
+	automato := filepath.Join(diretorio, "automato.json")
+	if err := salvarAutomato(automato, &referenciaTerminaAB); err != nil {
+		t.Fatal(err)
+	}
+	escrever := func(nome, conteudo string) string {
+		caminho := filepath.Join(diretorio, nome)
+		if err := os.WriteFile(caminho, []byte(conteudo), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return caminho
+	}
+
+	tests := []struct {
+		name     string
+		suite    string
+		expected int
+	}{
+		{"todos passam", "+ ab\n- ε\n", 0},
+		{"com falha", "+ ba\n", 1},
+		{"suíte inválida", "ab\n", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var saida strings.Builder
+			suite := escrever("suite.txt", tt.suite)
+			if got := executarComando([]string{"testar", automato, suite}, &saida); got != tt.expected {
+				t.Errorf("código de saída = %d, want %d (saída: %s)", got, tt.expected, saida.String())
+			}
+		})
+	}
+}
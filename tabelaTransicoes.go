@@ -0,0 +1,363 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Marcadores de estado inicial e final na primeira coluna da tabela, como nos livros-texto.
+const (
+	marcadorInicial = "→"
+	marcadorFinal   = "*"
+	celulaVazia     = "∅"
+)
+
+// TabelaTransicoes é a forma tabular do autômato: uma linha por estado, uma coluna por símbolo do
+// alfabeto (mais ε, quando há transições épsilon) e, em cada célula, o conjunto de destinos.
+type TabelaTransicoes struct {
+	Simbolos []rune
+	Epsilon  bool // a última coluna é a das transições épsilon
+	Linhas   []LinhaTabela
+}
+
+type LinhaTabela struct {
+	Estado   string
+	Inicial  bool
+	Final    bool
+	Destinos [][]string // uma célula por símbolo, na ordem de Simbolos, e por fim a de ε
+}
+
+// tabelaTransicoes monta a tabela na ordem de Estados e Alfabeto. Os destinos de cada célula
+// também seguem a ordem de Estados. Uma transição com estado fora de Estados ou símbolo fora do
+// Alfabeto não teria onde aparecer na tabela, então é um erro.
+func tabelaTransicoes(AF *AutomatoFinito) (TabelaTransicoes, error) {
+	if err := verificarDeclarados(AF); err != nil {
+		return TabelaTransicoes{}, err
+	}
+	T := TabelaTransicoes{Simbolos: slices.Clone(AF.Alfabeto)}
+	for _, destinos := range AF.TransicoesEpsilon {
+		if len(destinos) > 0 {
+			T.Epsilon = true
+			break
+		}
+	}
+	celula := func(alcancados []string) []string {
+		var destinos []string
+		for _, destino := range AF.Estados {
+			if slices.Contains(alcancados, destino) {
+				destinos = append(destinos, destino)
+			}
+		}
+		return destinos
+	}
+	for _, estado := range AF.Estados {
+		linha := LinhaTabela{
+			Estado:  estado,
+			Inicial: estado == AF.EstadoInicial,
+			Final:   slices.Contains(AF.EstadosFinais, estado),
+		}
+		for _, simbolo := range T.Simbolos {
+			linha.Destinos = append(linha.Destinos, celula(AF.Transicoes[estado][simbolo]))
+		}
+		if T.Epsilon {
+			linha.Destinos = append(linha.Destinos, celula(AF.TransicoesEpsilon[estado]))
+		}
+		T.Linhas = append(T.Linhas, linha)
+	}
+	return T, nil
+}
+
+// verificarDeclarados retorna um erro para o primeiro estado inicial ou final fora de Estados e para a
+// primeira transição, em ordem natural de origem, que usa um estado fora de Estados ou um símbolo
+// fora do Alfabeto.
+func verificarDeclarados(AF *AutomatoFinito) error {
+	if AF.EstadoInicial != "" && !slices.Contains(AF.Estados, AF.EstadoInicial) {
+		return fmt.Errorf("estado inicial '%s' não está entre os estados", AF.EstadoInicial)
+	}
+	for _, final := range AF.EstadosFinais {
+		if !slices.Contains(AF.Estados, final) {
+			return fmt.Errorf("estado final '%s' não está entre os estados", final)
+		}
+	}
+	verificarEstados := func(transicao string, estados ...string) error {
+		for _, estado := range estados {
+			if !slices.Contains(AF.Estados, estado) {
+				return fmt.Errorf("transição %s: estado '%s' não está entre os estados", transicao, estado)
+			}
+		}
+		return nil
+	}
+	for _, origem := range chavesOrdenadas(AF.Transicoes) {
+		for _, simbolo := range slices.Sorted(maps.Keys(AF.Transicoes[origem])) {
+			for _, destino := range AF.Transicoes[origem][simbolo] {
+				transicao := fmt.Sprintf("%s,'%s' --> %s", origem, formatarSimbolo(simbolo), destino)
+				if !slices.Contains(AF.Alfabeto, simbolo) {
+					return fmt.Errorf("transição %s: símbolo '%s' não está no alfabeto", transicao, formatarSimbolo(simbolo))
+				}
+				if err := verificarEstados(transicao, origem, destino); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for _, origem := range chavesOrdenadas(AF.TransicoesEpsilon) {
+		for _, destino := range AF.TransicoesEpsilon[origem] {
+			if err := verificarEstados(fmt.Sprintf("%s,ε --> %s", origem, destino), origem, destino); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// codificarEstadoTabela escreve o nome do estado com os escapes de codificarCadeia e escapa o que
+// seria lido como marcador (→, -> ou * no início) ou como célula vazia (- ou ∅).
+func codificarEstadoTabela(estado string) string {
+	texto := codificarCadeia(estado)
+	for _, especial := range []string{marcadorInicial, "->", marcadorFinal} {
+		if strings.HasPrefix(texto, especial) {
+			return `\` + texto
+		}
+	}
+	if texto == "-" || texto == celulaVazia {
+		return `\` + texto
+	}
+	return texto
+}
+
+// celulas retorna a tabela como texto, com o cabeçalho na primeira linha. Os símbolos do cabeçalho
+// usam os escapes de codificarCadeia (o símbolo ε aparece como \ε) e os estados, os de
+// codificarEstadoTabela. Uma célula com um único destino mostra só o nome; com vários, o conjunto
+// entre chaves, com as vírgulas dos nomes escapadas; vazia, ∅.
+func (T TabelaTransicoes) celulas() [][]string {
+	cabecalho := []string{""}
+	for _, simbolo := range T.Simbolos {
+		cabecalho = append(cabecalho, codificarCadeia(string(simbolo)))
+	}
+	if T.Epsilon {
+		cabecalho = append(cabecalho, string(epsilonRune))
+	}
+	celulas := [][]string{cabecalho}
+	for _, linha := range T.Linhas {
+		marcadores := ""
+		if linha.Inicial {
+			marcadores += marcadorInicial
+		}
+		if linha.Final {
+			marcadores += marcadorFinal
+		}
+		texto := []string{marcadores + codificarEstadoTabela(linha.Estado)}
+		for _, destinos := range linha.Destinos {
+			switch len(destinos) {
+			case 0:
+				texto = append(texto, celulaVazia)
+			case 1:
+				texto = append(texto, codificarEstadoTabela(destinos[0]))
+			default:
+				var nomes []string
+				for _, destino := range destinos {
+					nomes = append(nomes, strings.ReplaceAll(codificarEstadoTabela(destino), ",", `\,`))
+				}
+				texto = append(texto, "{"+strings.Join(nomes, ",")+"}")
+			}
+		}
+		celulas = append(celulas, texto)
+	}
+	return celulas
+}
+
+func (T TabelaTransicoes) paraCSV() string {
+	var sb strings.Builder
+	escritor := csv.NewWriter(&sb)
+	escritor.WriteAll(T.celulas()) // escrever num strings.Builder não falha
+	return sb.String()
+}
+
+// paraMarkdown alinha as colunas pelo número de caracteres, para que a tabela também fique legível
+// como texto puro.
+func (T TabelaTransicoes) paraMarkdown() string {
+	celulas := T.celulas()
+	larguras := make([]int, len(celulas[0]))
+	for _, linha := range celulas {
+		for j, celula := range linha {
+			celula = strings.ReplaceAll(celula, "|", `\|`)
+			linha[j] = celula
+			larguras[j] = max(larguras[j], utf8.RuneCountInString(celula), 1)
+		}
+	}
+	var sb strings.Builder
+	escreverLinha := func(linha []string) {
+		sb.WriteString("|")
+		for j, celula := range linha {
+			fmt.Fprintf(&sb, " %s%s |", celula, strings.Repeat(" ", larguras[j]-utf8.RuneCountInString(celula)))
+		}
+		sb.WriteString("\n")
+	}
+	escreverLinha(celulas[0])
+	sb.WriteString("|")
+	for _, largura := range larguras {
+		sb.WriteString(strings.Repeat("-", largura+2) + "|")
+	}
+	sb.WriteString("\n")
+	for _, linha := range celulas[1:] {
+		escreverLinha(linha)
+	}
+	return sb.String()
+}
+
+// tabelaDeCSV lê uma tabela no formato de paraCSV. No cabeçalho, "ε" ou "eps" é a coluna épsilon
+// e os demais símbolos são decodificados por decodificarCadeia; na primeira coluna, os marcadores
+// → (ou ->) e * podem vir em qualquer ordem antes do nome. Uma célula pode ser vazia, ∅, um estado
+// ou um conjunto {p,q}. Os nomes de estado também são decodificados, de modo que \* ou \∅ no início
+// fazem parte do nome.
+func tabelaDeCSV(conteudo string) (AutomatoFinito, error) {
+	leitor := csv.NewReader(strings.NewReader(conteudo))
+	registros, err := leitor.ReadAll()
+	if err != nil {
+		return AutomatoFinito{}, err
+	}
+	if len(registros) == 0 {
+		return AutomatoFinito{}, fmt.Errorf("tabela vazia")
+	}
+
+	AF := AutomatoFinito{}
+	colunaEpsilon := -1
+	simbolos := make([]rune, len(registros[0])-1)
+	for j, texto := range registros[0][1:] {
+		texto = strings.TrimSpace(texto)
+		if texto == string(epsilonRune) || texto == "eps" {
+			if colunaEpsilon >= 0 {
+				return AutomatoFinito{}, fmt.Errorf("linha 1, coluna %d: coluna ε repetida", j+2)
+			}
+			colunaEpsilon = j
+			continue
+		}
+		decodificado, err := decodificarCadeia(texto)
+		if err != nil {
+			return AutomatoFinito{}, fmt.Errorf("linha 1, coluna %d: %w", j+2, err)
+		}
+		simbolo, err := simboloDeTexto(decodificado)
+		if err != nil {
+			return AutomatoFinito{}, fmt.Errorf("linha 1, coluna %d: %w", j+2, err)
+		}
+		if slices.Contains(AF.Alfabeto, simbolo) {
+			return AutomatoFinito{}, fmt.Errorf("linha 1, coluna %d: símbolo %q repetido", j+2, simbolo)
+		}
+		simbolos[j] = simbolo
+		AF.adicionarAlfabeto(simbolo)
+	}
+
+	// As células só podem ser interpretadas depois de conhecer todos os estados, porque um nome como
+	// "{p,q}" (gerado pela determinização) é um estado, e não um conjunto
+	type celula struct {
+		linha, coluna int
+		origem        string
+		simbolo       rune
+		epsilon       bool
+		texto         string
+	}
+	var celulas []celula
+	for i, registro := range registros[1:] {
+		numero := i + 2
+		estado := strings.TrimSpace(registro[0])
+		inicial, final := false, false
+		for {
+			if resto, ok := strings.CutPrefix(estado, marcadorInicial); ok {
+				estado, inicial = resto, true
+			} else if resto, ok := strings.CutPrefix(estado, "->"); ok {
+				estado, inicial = resto, true
+			} else if resto, ok := strings.CutPrefix(estado, marcadorFinal); ok {
+				estado, final = resto, true
+			} else {
+				break
+			}
+		}
+		estado, err := decodificarCadeia(strings.TrimSpace(estado))
+		switch {
+		case err != nil:
+			return AutomatoFinito{}, fmt.Errorf("linha %d: %w", numero, err)
+		case estado == "":
+			return AutomatoFinito{}, fmt.Errorf("linha %d: nome de estado vazio", numero)
+		case slices.Contains(AF.Estados, estado):
+			return AutomatoFinito{}, fmt.Errorf("linha %d: estado '%s' repetido", numero, estado)
+		case inicial && AF.EstadoInicial != "":
+			return AutomatoFinito{}, fmt.Errorf("linha %d: mais de um estado inicial", numero)
+		}
+		AF.adicionarEstado(estado)
+		if inicial {
+			AF.adicionarEstadoInicial(estado)
+		}
+		if final {
+			AF.adicionarEstadoFinal(estado)
+		}
+		for j, texto := range registro[1:] {
+			celulas = append(celulas, celula{numero, j + 2, estado, simbolos[j], j == colunaEpsilon, texto})
+		}
+	}
+
+	for _, c := range celulas {
+		destinos, err := lerCelula(c.texto, AF.Estados)
+		if err != nil {
+			return AutomatoFinito{}, fmt.Errorf("linha %d, coluna %d: %w", c.linha, c.coluna, err)
+		}
+		for _, destino := range destinos {
+			if !slices.Contains(AF.Estados, destino) {
+				return AutomatoFinito{}, fmt.Errorf("linha %d, coluna %d: estado '%s' não tem linha na tabela", c.linha, c.coluna, destino)
+			}
+			switch {
+			case c.epsilon && !slices.Contains(AF.TransicoesEpsilon[c.origem], destino):
+				AF.adicionarTransicaoEpsilon(c.origem, destino)
+			case !c.epsilon && !slices.Contains(AF.Transicoes[c.origem][c.simbolo], destino):
+				AF.adicionarTransicao(c.origem, c.simbolo, destino)
+			}
+		}
+	}
+	if AF.EstadoInicial == "" {
+		return AutomatoFinito{}, fmt.Errorf("nenhum estado marcado como inicial (%s)", marcadorInicial)
+	}
+	return AF, nil
+}
+
+// lerCelula separa os destinos de uma célula. Um texto que é o nome de um estado vale como esse
+// estado, mesmo que tenha chaves; num conjunto, só as vírgulas sem barra invertida separam destinos.
+func lerCelula(texto string, estados []string) ([]string, error) {
+	texto = strings.TrimSpace(texto)
+	if texto == "" || texto == celulaVazia || texto == "-" {
+		return nil, nil
+	}
+	estado, err := decodificarCadeia(texto)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(estados, estado) || !strings.HasPrefix(texto, "{") || !strings.HasSuffix(texto, "}") {
+		return []string{estado}, nil
+	}
+	var itens []string
+	interno, inicio := texto[1:len(texto)-1], 0
+	for i := 0; i < len(interno); i++ {
+		switch interno[i] {
+		case '\\':
+			i++
+		case ',':
+			itens = append(itens, interno[inicio:i])
+			inicio = i + 1
+		}
+	}
+	itens = append(itens, interno[inicio:])
+	var destinos []string
+	for _, item := range itens {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		destino, err := decodificarCadeia(item)
+		if err != nil {
+			return nil, err
+		}
+		destinos = append(destinos, destino)
+	}
+	return destinos, nil
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// automatoTabela é o AFN de "termina com ab" com uma transição épsilon extra.
+var automatoTabela = AutomatoFinito{
+	Estados:  []string{"q0", "q1", "q2"},
+	Alfabeto: []rune{'a', 'b'},
+	Transicoes: map[string]map[rune][]string{
+		"q0": {'a': {"q1", "q0"}, 'b': {"q0"}},
+		"q1": {'b': {"q2"}},
+	},
+	TransicoesEpsilon: map[string][]string{"q2": {"q0"}},
+	EstadoInicial:     "q0",
+	EstadosFinais:     []string{"q2"},
+}
+
+func TestTabelaTransicoesMarkdown(t *testing.T) {
+	expected := "" +
+		"|     | a       | b  | ε  |\n" +
+		"|-----|---------|----|----|\n" +
+		"| →q0 | {q0,q1} | q0 | ∅  |\n" +
+		"| q1  | ∅       | q2 | ∅  |\n" +
+		"| *q2 | ∅       | ∅  | q0 |\n"
+	T, _ := tabelaTransicoes(&automatoTabela)
+	if got := T.paraMarkdown(); got != expected {
+		t.Errorf("paraMarkdown() =\n%s\nwant\n%s", got, expected)
+	}
+}
+
+func TestTabelaTransicoesCSV(t *testing.T) {
+	T, _ := tabelaTransicoes(&automatoTabela)
+	csv := T.paraCSV()
+	expected := ",a,b,ε\n→q0,\"{q0,q1}\",q0,∅\nq1,∅,q2,∅\n*q2,∅,∅,q0\n"
+	if csv != expected {
+		t.Errorf("paraCSV() = %q, want %q", csv, expected)
+	}
+
+	AF, err := tabelaDeCSV(csv)
+	if err != nil {
+		t.Fatalf("tabelaDeCSV() erro inesperado: %v", err)
+	}
+	// A tabela ordena os destinos pela ordem dos estados
+	original := automatoTabela.clonar()
+	original.Transicoes["q0"]['a'] = []string{"q0", "q1"}
+	if !reflect.DeepEqual(AF, original) {
+		t.Errorf("tabelaDeCSV() = %+v, want %+v", AF, original)
+	}
+}
+
+func TestTabelaDeCSVFormasAlternativas(t *testing.T) {
+	// Estado com nome de conjunto (como os da determinização), marcadores "->" e "*->", células vazias
+	csv := ",a,eps\n->{q0},\"{q0},{q1}\",\n*->x,,\n"
+	if _, err := tabelaDeCSV(csv); err == nil || !strings.Contains(err.Error(), "mais de um estado inicial") {
+		t.Errorf("tabelaDeCSV() erro = %v, want mais de um inicial", err)
+	}
+
+	csv = ",a,eps\n->{q0},\"{{q0},x}\",\n*x,{q0},x\n"
+	AF, err := tabelaDeCSV(csv)
+	if err != nil {
+		t.Fatalf("tabelaDeCSV() erro inesperado: %v", err)
+	}
+	expected := map[string]map[rune][]string{
+		"{q0}": {'a': {"{q0}", "x"}},
+		"x":    {'a': {"{q0}"}},
+	}
+	expectedEpsilon := map[string][]string{"x": {"x"}}
+	if !reflect.DeepEqual(AF.Transicoes, expected) || !reflect.DeepEqual(AF.TransicoesEpsilon, expectedEpsilon) || AF.EstadoInicial != "{q0}" || !reflect.DeepEqual(AF.EstadosFinais, []string{"x"}) {
+		t.Errorf("tabelaDeCSV() = %+v", AF)
+	}
+
+	tests := []struct {
+		csv    string
+		trecho string
+	}{
+		{",a\n→q0,q9\n", "linha 2, coluna 2: estado 'q9' não tem linha"},
+		{",ab\n→q0,q0\n", "linha 1, coluna 2"},
+		{",a\nq0,q0\n", "nenhum estado marcado como inicial"},
+		{",a\n→q0,q0\nq0,q0\n", "linha 3: estado 'q0' repetido"},
+		{",a\n→q0\n", "wrong number of fields"},
+	}
+	for _, tt := range tests {
+		if _, err := tabelaDeCSV(tt.csv); err == nil || !strings.Contains(err.Error(), tt.trecho) {
+			t.Errorf("tabelaDeCSV(%q) erro = %v, want contendo %q", tt.csv, err, tt.trecho)
+		}
+	}
+}
+
+func TestTabelaTransicoesNomesEspeciais(t *testing.T) {
+	// Nomes que seriam lidos como marcadores, célula vazia ou conjunto voltam iguais
+	AF := AutomatoFinito{}
+	for _, estado := range []string{"*x", "→y", "->z", "-", "∅", "a,b", `\w`, "q 0"} {
+		AF.adicionarEstado(estado)
+	}
+	AF.adicionarAlfabeto('a')
+	AF.adicionarTransicao("*x", 'a', "-")
+	AF.adicionarTransicao("*x", 'a', "a,b")
+	AF.adicionarTransicao("→y", 'a', "∅")
+	AF.adicionarTransicao("-", 'a', `\w`)
+	AF.adicionarTransicao("∅", 'a', "q 0")
+	AF.adicionarTransicaoEpsilon("->z", "*x")
+	AF.adicionarEstadoInicial("*x")
+	AF.adicionarEstadoFinal("∅")
+
+	T, err := tabelaTransicoes(&AF)
+	if err != nil {
+		t.Fatalf("tabelaTransicoes() erro inesperado: %v", err)
+	}
+	csv := T.paraCSV()
+	expected := ",a,ε\n→\\*x,\"{\\-,a\\,b}\",∅\n\\→y,\\∅,∅\n\\->z,∅,\\*x\n\\-,\\\\w,∅\n*\\∅,q\\s0,∅\n\"a,b\",∅,∅\n\\\\w,∅,∅\nq\\s0,∅,∅\n"
+	if csv != expected {
+		t.Errorf("paraCSV() = %q, want %q", csv, expected)
+	}
+	lido, err := tabelaDeCSV(csv)
+	if err != nil {
+		t.Fatalf("tabelaDeCSV() erro inesperado: %v", err)
+	}
+	if !reflect.DeepEqual(lido, AF) {
+		t.Errorf("tabelaDeCSV() = %+v, want %+v", lido, AF)
+	}
+}
+
+func TestTabelaTransicoesNaoDeclarados(t *testing.T) {
+	tests := []struct {
+		AF     AutomatoFinito
+		trecho string
+	}{
+		{AutomatoFinito{
+			Estados:    []string{"q0"},
+			Alfabeto:   []rune{'a'},
+			Transicoes: map[string]map[rune][]string{"q0": {'a': {"q1"}}},
+		}, "transição q0,'a' --> q1: estado 'q1' não está entre os estados"},
+		{AutomatoFinito{
+			Estados:    []string{"q0"},
+			Alfabeto:   []rune{'a'},
+			Transicoes: map[string]map[rune][]string{"q0": {'b': {"q0"}}},
+		}, "transição q0,'b' --> q0: símbolo 'b' não está no alfabeto"},
+		{AutomatoFinito{
+			Estados:           []string{"q0"},
+			TransicoesEpsilon: map[string][]string{"q1": {"q0"}},
+		}, "transição q1,ε --> q0: estado 'q1'"},
+		{AutomatoFinito{
+			Estados:       []string{"q0"},
+			EstadoInicial: "q0",
+			EstadosFinais: []string{"q2"},
+		}, "estado final 'q2' não está entre os estados"},
+	}
+	for _, tt := range tests {
+		if _, err := tabelaTransicoes(&tt.AF); err == nil || !strings.Contains(err.Error(), tt.trecho) {
+			t.Errorf("tabelaTransicoes(%+v) erro = %v, want contendo %q", tt.AF, err, tt.trecho)
+		}
+	}
+}
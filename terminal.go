@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ehTerminal indica se a entrada padrão é um terminal interativo (e não um arquivo ou pipe).
+func ehTerminal() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// stty executa o comando stty sobre o terminal da entrada padrão.
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	saida, err := cmd.Output()
+	return strings.TrimSpace(string(saida)), err
+}
+
+// lerLinhaEditavel lê uma linha com o prompt dado. No terminal, coloca-o em modo cru para tratar
+// Tab (completar), Backspace, Ctrl-C (descarta a linha) e Ctrl-D (fim da entrada, com a linha
+// vazia). Fora de um terminal, ou se stty não estiver disponível, lê a linha normalmente.
+func lerLinhaEditavel(prompt string, completarLinha func(string) (string, []string)) (string, bool) {
+	fmt.Print(prompt)
+	if !ehTerminal() {
+		return lerLinha()
+	}
+	estado, err := stty("-g")
+	if err != nil {
+		return lerLinha()
+	}
+	if _, err := stty("raw", "-echo"); err != nil {
+		return lerLinha()
+	}
+	defer stty(estado)
+
+	var linha []rune
+	for {
+		r, _, err := entradaPadrao.ReadRune()
+		if err != nil {
+			fmt.Print("\r\n")
+			return string(linha), len(linha) > 0
+		}
+		switch r {
+		case '\r', '\n':
+			fmt.Print("\r\n")
+			return string(linha), true
+		case 3: // Ctrl-C
+			fmt.Print("^C\r\n")
+			return "", true
+		case 4: // Ctrl-D
+			if len(linha) == 0 {
+				return "", false
+			}
+		case 127, '\b':
+			if len(linha) > 0 {
+				linha = linha[:len(linha)-1]
+				fmt.Print("\b \b")
+			}
+		case '\t':
+			completada, opcoes := completarLinha(string(linha))
+			if len(opcoes) > 0 && completada == string(linha) {
+				fmt.Printf("\r\n%s\r\n%s%s", strings.Join(opcoes, "  "), prompt, completada)
+			} else {
+				fmt.Print(strings.TrimPrefix(completada, string(linha)))
+			}
+			linha = []rune(completada)
+		case 27: // sequências de escape (setas etc.) são ignoradas
+			if proximo, _, err := entradaPadrao.ReadRune(); err == nil && proximo == '[' {
+				for {
+					final, _, err := entradaPadrao.ReadRune()
+					if err != nil || (final >= '@' && final <= '~') {
+						break
+					}
+				}
+			}
+		default:
+			if r >= ' ' {
+				linha = append(linha, r)
+				fmt.Print(string(r))
+			}
+		}
+	}
+}
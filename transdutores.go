@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+)
+
+// MaquinaMoore é um transdutor em que cada estado tem uma saída. Estados, alfabeto de entrada e
+// transições seguem o modelo de AutomatoFinito; EstadosFinais não é usado.
+type MaquinaMoore struct {
+	AutomatoFinito
+	Saidas map[string]string // estado: saída
+}
+
+// MaquinaMealy é um transdutor em que cada transição tem uma saída.
+type MaquinaMealy struct {
+	AutomatoFinito
+	Saidas map[string]map[rune]string // estadoOrigem: [símbolo: saída]
+}
+
+func (M *MaquinaMoore) adicionarSaida(estado string, saida string) {
+	if M.Saidas == nil {
+		M.Saidas = make(map[string]string)
+	}
+	M.Saidas[estado] = saida
+}
+
+func (M *MaquinaMealy) adicionarTransicaoSaida(estadoOrigem string, simbolo rune, estadoDestino string, saida string) {
+	M.adicionarTransicao(estadoOrigem, simbolo, estadoDestino)
+	if M.Saidas == nil {
+		M.Saidas = make(map[string]map[rune]string)
+	}
+	if M.Saidas[estadoOrigem] == nil {
+		M.Saidas[estadoOrigem] = make(map[rune]string)
+	}
+	M.Saidas[estadoOrigem][simbolo] = saida
+}
+
+// proximoEstado segue a única transição de (estado, símbolo) de um transdutor determinístico.
+func (AF *Automato[S]) proximoEstado(estado string, simbolo S) (string, error) {
+	destinos := AF.Transicoes[estado][simbolo]
+	if len(destinos) == 0 {
+		return "", fmt.Errorf("sem transição para (%s, '%s')", estado, formatarSimbolo(simbolo))
+	}
+	return destinos[0], nil
+}
+
+// executar lê a entrada e retorna a sequência de saídas: a do estado inicial seguida da saída de
+// cada estado visitado (n+1 saídas para n símbolos).
+func (M *MaquinaMoore) executar(entrada string) ([]string, error) {
+	if !M.ehDeterministico() {
+		return nil, errNaoDeterministico
+	}
+	estado := M.EstadoInicial
+	saidas := []string{M.Saidas[estado]}
+	for _, simbolo := range entrada {
+		var err error
+		if estado, err = M.proximoEstado(estado, simbolo); err != nil {
+			return saidas, err
+		}
+		saidas = append(saidas, M.Saidas[estado])
+	}
+	return saidas, nil
+}
+
+// executar lê a entrada e retorna a saída de cada transição percorrida (n saídas para n símbolos).
+func (M *MaquinaMealy) executar(entrada string) ([]string, error) {
+	if !M.ehDeterministico() {
+		return nil, errNaoDeterministico
+	}
+	estado := M.EstadoInicial
+	var saidas []string
+	for _, simbolo := range entrada {
+		proximo, err := M.proximoEstado(estado, simbolo)
+		if err != nil {
+			return saidas, err
+		}
+		saidas = append(saidas, M.Saidas[estado][simbolo])
+		estado = proximo
+	}
+	return saidas, nil
+}
+
+// paraMealy converte a máquina de Moore em uma de Mealy com os mesmos estados: cada transição
+// passa a emitir a saída do seu estado de destino. A saída do estado inicial, que a Mealy não
+// produz, é descartada.
+func (M *MaquinaMoore) paraMealy() MaquinaMealy {
+	mealy := MaquinaMealy{AutomatoFinito: AutomatoFinito{
+		Estados:       slices.Clone(M.Estados),
+		Alfabeto:      slices.Clone(M.Alfabeto),
+		EstadoInicial: M.EstadoInicial,
+	}}
+	for _, origem := range M.Estados {
+		for _, simbolo := range M.simbolosOrdenados() {
+			for _, destino := range M.Transicoes[origem][simbolo] {
+				mealy.adicionarTransicaoSaida(origem, simbolo, destino, M.Saidas[destino])
+			}
+		}
+	}
+	return mealy
+}
+
+// paraMoore converte a máquina de Mealy em uma de Moore. Cada estado q que é alcançado com saídas
+// diferentes é dividido em estados "q/saída"; os demais mantêm o nome. O estado inicial mantém o
+// nome original e saída vazia. Só a parte alcançável a partir do estado inicial é gerada.
+func (M *MaquinaMealy) paraMoore() (MaquinaMoore, error) {
+	if !M.ehDeterministico() {
+		return MaquinaMoore{}, errNaoDeterministico
+	}
+
+	type estadoSaida struct {
+		estado, saida string
+	}
+	type transicaoPar struct {
+		origem  estadoSaida
+		simbolo rune
+		destino estadoSaida
+	}
+
+	// Primeiro os pares (estado, saída) alcançáveis, para saber quais estados precisam ser divididos
+	simbolos := M.simbolosOrdenados()
+	inicial := estadoSaida{M.EstadoInicial, ""}
+	pares := []estadoSaida{inicial}
+	vistos := map[estadoSaida]bool{inicial: true}
+	var transicoes []transicaoPar
+	saidasPorEstado := map[string]int{M.EstadoInicial: 1}
+	for i := 0; i < len(pares); i++ {
+		atual := pares[i]
+		for _, simbolo := range simbolos {
+			destinos := M.Transicoes[atual.estado][simbolo]
+			if len(destinos) == 0 {
+				continue
+			}
+			proximo := estadoSaida{destinos[0], M.Saidas[atual.estado][simbolo]}
+			if !vistos[proximo] {
+				vistos[proximo] = true
+				pares = append(pares, proximo)
+				saidasPorEstado[proximo.estado]++
+			}
+			transicoes = append(transicoes, transicaoPar{atual, simbolo, proximo})
+		}
+	}
+
+	// Os nomes originais ficam reservados antes de gerar os das divisões, para que um estado chamado
+	// "q/x" não colida com a divisão de q pela saída x
+	reservados := AutomatoFinito{Estados: slices.Clone(M.Estados)}
+	for _, par := range pares {
+		reservados.adicionarEstado(par.estado)
+	}
+	moore := MaquinaMoore{AutomatoFinito: AutomatoFinito{Alfabeto: slices.Clone(M.Alfabeto)}}
+	nomes := make(map[estadoSaida]string, len(pares))
+	for _, par := range pares {
+		nome := par.estado
+		if par != inicial && saidasPorEstado[par.estado] > 1 {
+			nome = reservados.nomeNovoEstado(par.estado + "/" + par.saida)
+			reservados.adicionarEstado(nome)
+		}
+		nomes[par] = nome
+		moore.adicionarEstado(nome)
+		moore.adicionarSaida(nome, par.saida)
+	}
+	moore.adicionarEstadoInicial(M.EstadoInicial)
+	for _, transicao := range transicoes {
+		moore.adicionarTransicao(nomes[transicao.origem], transicao.simbolo, nomes[transicao.destino])
+	}
+	return moore, nil
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// novaMooreParidade retorna uma máquina de Moore que emite a paridade de '1's lidos até o momento.
+func novaMooreParidade() MaquinaMoore {
+	M := MaquinaMoore{}
+	M.adicionarEstado("par")
+	M.adicionarEstado("impar")
+	M.adicionarAlfabeto('0')
+	M.adicionarAlfabeto('1')
+	M.adicionarTransicao("par", '0', "par")
+	M.adicionarTransicao("par", '1', "impar")
+	M.adicionarTransicao("impar", '0', "impar")
+	M.adicionarTransicao("impar", '1', "par")
+	M.adicionarEstadoInicial("par")
+	M.adicionarSaida("par", "P")
+	M.adicionarSaida("impar", "I")
+	return M
+}
+
+// novaMealyBorda retorna uma máquina de Mealy que emite "1" quando o bit lido difere do anterior.
+func novaMealyBorda() MaquinaMealy {
+	M := MaquinaMealy{}
+	M.adicionarEstado("s0")
+	M.adicionarEstado("s1")
+	M.adicionarAlfabeto('0')
+	M.adicionarAlfabeto('1')
+	M.adicionarTransicaoSaida("s0", '0', "s0", "0")
+	M.adicionarTransicaoSaida("s0", '1', "s1", "1")
+	M.adicionarTransicaoSaida("s1", '0', "s0", "1")
+	M.adicionarTransicaoSaida("s1", '1', "s1", "0")
+	M.adicionarEstadoInicial("s0")
+	return M
+}
+
+func TestExecutarMoore(t *testing.T) {
+	M := novaMooreParidade()
+	tests := []struct {
+		entrada  string
+		expected []string
+	}{
+		{"", []string{"P"}},
+		{"1", []string{"P", "I"}},
+		{"1101", []string{"P", "I", "P", "P", "I"}},
+	}
+	for _, tt := range tests {
+		got, err := M.executar(tt.entrada)
+		if err != nil {
+			t.Fatalf("executar(%q) erro inesperado: %v", tt.entrada, err)
+		}
+		if !slices.Equal(got, tt.expected) {
+			t.Errorf("executar(%q) = %v, want %v", tt.entrada, got, tt.expected)
+		}
+	}
+
+	if _, err := M.executar("12"); err == nil {
+		t.Errorf("executar com símbolo sem transição deveria retornar erro")
+	}
+}
+
+func TestExecutarMealy(t *testing.T) {
+	M := novaMealyBorda()
+	got, err := M.executar("0110")
+	if err != nil {
+		t.Fatalf("executar() erro inesperado: %v", err)
+	}
+	if expected := []string{"0", "1", "0", "1"}; !slices.Equal(got, expected) {
+		t.Errorf("executar(\"0110\") = %v, want %v", got, expected)
+	}
+}
+
+func TestConversaoMooreMealy(t *testing.T) {
+	moore := novaMooreParidade()
+	mealy := moore.paraMealy()
+
+	borda := novaMealyBorda()
+	mooreBorda, err := borda.paraMoore()
+	if err != nil {
+		t.Fatalf("paraMoore() erro inesperado: %v", err)
+	}
+	// s0 é alcançado com saída "" (inicial), "0" e "1"; s1 com "1" e "0"
+	if len(mooreBorda.Estados) != 5 {
+		t.Errorf("paraMoore() gerou %d estados, want 5: %v", len(mooreBorda.Estados), mooreBorda.Estados)
+	}
+
+	// impar só é alcançado com saída "I" e mantém o nome; par também é alcançado com "P"
+	mooreParidade, err := mealy.paraMoore()
+	if err != nil {
+		t.Fatalf("paraMoore() erro inesperado: %v", err)
+	}
+	if expected := []string{"par", "par/P", "impar"}; !slices.Equal(mooreParidade.Estados, expected) {
+		t.Errorf("paraMoore() Estados = %v, want %v", mooreParidade.Estados, expected)
+	}
+
+	for _, entrada := range []string{"", "0", "1", "0110", "111000", "010101"} {
+		saidaMoore, _ := moore.executar(entrada)
+		saidaMealy, _ := mealy.executar(entrada)
+		if !slices.Equal(saidaMoore[1:], saidaMealy) {
+			t.Errorf("entrada %q: Moore = %v, Mealy convertida = %v", entrada, saidaMoore, saidaMealy)
+		}
+
+		saidaBorda, _ := borda.executar(entrada)
+		saidaMooreBorda, _ := mooreBorda.executar(entrada)
+		if !slices.Equal(saidaMooreBorda[1:], saidaBorda) {
+			t.Errorf("entrada %q: Mealy = %v, Moore convertida = %v", entrada, saidaBorda, saidaMooreBorda)
+		}
+	}
+}
+
+func TestParaMooreNomeOcupado(t *testing.T) {
+	// q é alcançado com as saídas x e y, e já existe um estado chamado "q/x", alcançado com z
+	M := MaquinaMealy{}
+	for _, estado := range []string{"s", "q", "q/x"} {
+		M.adicionarEstado(estado)
+	}
+	for _, simbolo := range "abc" {
+		M.adicionarAlfabeto(simbolo)
+	}
+	M.adicionarTransicaoSaida("s", 'a', "q", "x")
+	M.adicionarTransicaoSaida("s", 'b', "q", "y")
+	M.adicionarTransicaoSaida("s", 'c', "q/x", "z")
+	for _, simbolo := range "abc" {
+		M.adicionarTransicaoSaida("q", simbolo, "s", "w")
+		M.adicionarTransicaoSaida("q/x", simbolo, "q", "v")
+	}
+	M.adicionarEstadoInicial("s")
+
+	moore, err := M.paraMoore()
+	if err != nil {
+		t.Fatalf("paraMoore() erro inesperado: %v", err)
+	}
+	if expected := []string{"s", "q/x1", "q/y", "q/x", "s/w", "q/v"}; !slices.Equal(moore.Estados, expected) {
+		t.Errorf("paraMoore() Estados = %v, want %v", moore.Estados, expected)
+	}
+	for _, entrada := range []string{"a", "ba", "cab", "cc", "acbcab"} {
+		saidaMealy, _ := M.executar(entrada)
+		saidaMoore, _ := moore.executar(entrada)
+		if !slices.Equal(saidaMoore[1:], saidaMealy) {
+			t.Errorf("entrada %q: Mealy = %v, Moore convertida = %v", entrada, saidaMealy, saidaMoore)
+		}
+	}
+}